@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -68,17 +67,13 @@ func (c *Client) GetPaginationAdapters(p int, pageSize int, queryMap map[string]
 
 	url := c.GetUrl("get-adapters", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var adapters []*Adapter
+	total, err := c.doGetPagination(url, &adapters)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	adapters, ok := response.Data.([]*Adapter)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return adapters, int(response.Data2.(float64)), nil
+	return adapters, total, nil
 }
 
 func (c *Client) GetAdapter(name string) (*Adapter, error) {
@@ -115,3 +110,24 @@ func (c *Client) DeleteAdapter(adapter *Adapter) (bool, error) {
 	_, affected, err := c.modifyAdapter("delete-adapter", adapter, nil)
 	return affected, err
 }
+
+// TestAdapterConnection asks Casdoor to connect to the database of the adapter and to check that its table exists.
+// The error holds the reason reported by Casdoor when the adapter cannot be used. An adapter without owner
+// is looked up in the client's organization.
+func (c *Client) TestAdapterConnection(adapter *Adapter) error {
+	owner := adapter.Owner
+	if owner == "" {
+		owner = c.OrganizationName
+	}
+	queryMap := map[string]string{
+		"id": fmt.Sprintf("%s/%s", owner, adapter.Name),
+	}
+
+	postBytes, err := json.Marshal(adapter)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.DoPost("test-adapter-connection", queryMap, postBytes, false, false)
+	return err
+}
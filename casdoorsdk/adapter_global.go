@@ -37,3 +37,7 @@ func AddAdapter(adapter *Adapter) (bool, error) {
 func DeleteAdapter(adapter *Adapter) (bool, error) {
 	return globalClient.DeleteAdapter(adapter)
 }
+
+func TestAdapterConnection(adapter *Adapter) error {
+	return globalClient.TestAdapterConnection(adapter)
+}
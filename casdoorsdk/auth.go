@@ -14,19 +14,49 @@
 
 package casdoorsdk
 
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 // AuthConfig is the core configuration.
 // The first step to use this SDK is to use the InitConfig function to initialize the global authConfig.
 type AuthConfig struct {
-	Endpoint         string
-	ClientId         string
-	ClientSecret     string
+	Endpoint     string
+	ClientId     string
+	ClientSecret string
+	// Certificate is the PEM encoded certificate or public key tokens are verified with,
+	// it may be a bundle of several PEM blocks to trust several certs. Once the client is in use,
+	// read and replace it with GetCertificate and SetCertificate.
 	Certificate      string
 	OrganizationName string
 	ApplicationName  string
+	// Certs are trusted in addition to Certificate, a token whose "kid" header
+	// matches the name of one of them is only verified with that cert.
+	Certs []*Cert
 }
 
 type Client struct {
 	AuthConfig
+
+	certMutex    sync.RWMutex
+	retiredCerts []*retiredCert
+
+	// decisionCache holds a *decisionCache, see EnableDecisionCache
+	decisionCache atomic.Value
+
+	// passwordOptions maps organization names to *cachedPasswordOptions, see validateUserPassword
+	passwordOptions sync.Map
+
+	// permissionSnapshots maps permission ids to *cachedPermissionSnapshot, see explainPermission
+	permissionSnapshots sync.Map
+}
+
+// retiredCert is a cert still trusted for verification until it expires, see TrustRetiredCert.
+type retiredCert struct {
+	cert  *Cert
+	until time.Time
 }
 
 var globalClient *Client
@@ -49,6 +79,60 @@ func NewClient(endpoint string, clientId string, clientSecret string, certificat
 
 func NewClientWithConf(config *AuthConfig) *Client {
 	return &Client{
-		*config,
+		AuthConfig: *config,
+	}
+}
+
+// SetCertificate replaces the certificate tokens are verified with, it is safe to call concurrently with ParseJwtToken.
+func (c *Client) SetCertificate(certificate string) {
+	c.certMutex.Lock()
+	defer c.certMutex.Unlock()
+
+	c.Certificate = certificate
+}
+
+// GetCertificate returns the certificate tokens are verified with, it is safe to call concurrently with SetCertificate.
+func (c *Client) GetCertificate() string {
+	c.certMutex.RLock()
+	defer c.certMutex.RUnlock()
+
+	return c.Certificate
+}
+
+// TrustRetiredCert keeps accepting tokens signed by cert until the given time,
+// so tokens issued before a cert rotation remain valid during the grace period.
+func (c *Client) TrustRetiredCert(cert *Cert, until time.Time) {
+	c.certMutex.Lock()
+	defer c.certMutex.Unlock()
+
+	now := time.Now()
+	retiredCerts := []*retiredCert{}
+	for _, retiredCert := range c.retiredCerts {
+		if now.Before(retiredCert.until) {
+			retiredCerts = append(retiredCerts, retiredCert)
+		}
+	}
+	c.retiredCerts = append(retiredCerts, &retiredCert{cert: cert, until: until})
+}
+
+// getTrustedCerts returns the certificate, the certs and the unexpired retired certs, in this order.
+// The certificate is returned as a cert without name.
+func (c *Client) getTrustedCerts() ([]*Cert, int) {
+	c.certMutex.RLock()
+	defer c.certMutex.RUnlock()
+
+	certs := []*Cert{}
+	if c.Certificate != "" {
+		certs = append(certs, &Cert{Certificate: c.Certificate})
+	}
+	certs = append(certs, c.Certs...)
+	retiredIndex := len(certs)
+
+	now := time.Now()
+	for _, retiredCert := range c.retiredCerts {
+		if now.Before(retiredCert.until) {
+			certs = append(certs, retiredCert.cert)
+		}
 	}
+	return certs, retiredIndex
 }
@@ -19,6 +19,7 @@ package casdoorsdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -51,7 +52,12 @@ type Response struct {
 
 // DoGetResponse is a general function to get response from param url through HTTP Get method.
 func (c *Client) DoGetResponse(url string) (*Response, error) {
-	respBytes, err := c.doGetBytesRawWithoutCheck(url)
+	return c.doGetResponseWithContext(context.Background(), url)
+}
+
+// doGetResponseWithContext is DoGetResponse, with the request canceled when ctx is done.
+func (c *Client) doGetResponseWithContext(ctx context.Context, url string) (*Response, error) {
+	respBytes, err := c.doGetBytesRawWithoutCheck(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +75,50 @@ func (c *Client) DoGetResponse(url string) (*Response, error) {
 	return &response, nil
 }
 
+// doGetWithContext gets the response of the action, and decodes its data into v, with the request canceled when ctx is done.
+func (c *Client) doGetWithContext(ctx context.Context, action string, queryMap map[string]string, v interface{}) error {
+	response, err := c.doGetResponseWithContext(ctx, c.GetUrl(action, queryMap))
+	if err != nil {
+		return err
+	}
+
+	dataBytes, err := json.Marshal(response.Data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(dataBytes, v)
+}
+
+// doGetPagination gets a page of a list from param url, decodes its data into v and returns the total count of the list.
+func (c *Client) doGetPagination(url string, v interface{}) (int, error) {
+	return c.doGetPaginationWithContext(context.Background(), url, v)
+}
+
+// doGetPaginationWithContext is doGetPagination, with the request canceled when ctx is done.
+func (c *Client) doGetPaginationWithContext(ctx context.Context, url string, v interface{}) (int, error) {
+	response, err := c.doGetResponseWithContext(ctx, url)
+	if err != nil {
+		return 0, err
+	}
+
+	dataBytes, err := json.Marshal(response.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	err = json.Unmarshal(dataBytes, v)
+	if err != nil {
+		return 0, err
+	}
+
+	total, ok := response.Data2.(float64)
+	if !ok {
+		return 0, errors.New("response data format is incorrect")
+	}
+	return int(total), nil
+}
+
 // DoGetBytes is a general function to get response data in bytes from param url through HTTP Get method.
 func (c *Client) DoGetBytes(url string) ([]byte, error) {
 	response, err := c.DoGetResponse(url)
@@ -86,7 +136,7 @@ func (c *Client) DoGetBytes(url string) ([]byte, error) {
 
 // DoGetBytesRaw is a general function to get response from param url through HTTP Get method.
 func (c *Client) DoGetBytesRaw(url string) ([]byte, error) {
-	respBytes, err := c.doGetBytesRawWithoutCheck(url)
+	respBytes, err := c.doGetBytesRawWithoutCheck(context.Background(), url)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +151,11 @@ func (c *Client) DoGetBytesRaw(url string) ([]byte, error) {
 }
 
 func (c *Client) DoPost(action string, queryMap map[string]string, postBytes []byte, isForm, isFile bool) (*Response, error) {
+	return c.doPostWithContext(context.Background(), action, queryMap, postBytes, isForm, isFile)
+}
+
+// doPostWithContext is DoPost, with the request canceled when ctx is done.
+func (c *Client) doPostWithContext(ctx context.Context, action string, queryMap map[string]string, postBytes []byte, isForm, isFile bool) (*Response, error) {
 	url := c.GetUrl(action, queryMap)
 
 	var err error
@@ -129,7 +184,7 @@ func (c *Client) DoPost(action string, queryMap map[string]string, postBytes []b
 		body = bytes.NewReader(postBytes)
 	}
 
-	respBytes, err := c.DoPostBytesRaw(url, contentType, body)
+	respBytes, err := c.DoPostBytesRawWithContext(ctx, url, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -149,13 +204,18 @@ func (c *Client) DoPost(action string, queryMap map[string]string, postBytes []b
 
 // DoPostBytesRaw is a general function to post a request from url, body through HTTP Post method.
 func (c *Client) DoPostBytesRaw(url string, contentType string, body io.Reader) ([]byte, error) {
+	return c.DoPostBytesRawWithContext(context.Background(), url, contentType, body)
+}
+
+// DoPostBytesRawWithContext is DoPostBytesRaw, with the request canceled when ctx is done.
+func (c *Client) DoPostBytesRawWithContext(ctx context.Context, url string, contentType string, body io.Reader) ([]byte, error) {
 	if contentType == "" {
 		contentType = "text/plain;charset=UTF-8"
 	}
 
 	var resp *http.Response
 
-	req, err := http.NewRequest("POST", url, body)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
 		return nil, err
 	}
@@ -183,8 +243,8 @@ func (c *Client) DoPostBytesRaw(url string, contentType string, body io.Reader)
 }
 
 // doGetBytesRawWithoutCheck is a general function to get response from param url through HTTP Get method without checking response status
-func (c *Client) doGetBytesRawWithoutCheck(url string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) doGetBytesRawWithoutCheck(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -238,6 +298,11 @@ func (c *Client) modifyOrganization(action string, organization *Organization, c
 // modifyApplication is an encapsulation of permission CUD(Create, Update, Delete) operations.
 // possible actions are `add-application`, `update-application`, `delete-application`,
 func (c *Client) modifyApplication(action string, application *Application, columns []string) (*Response, bool, error) {
+	return c.modifyApplicationWithContext(context.Background(), action, application, columns)
+}
+
+// modifyApplicationWithContext is modifyApplication, with the request canceled when ctx is done.
+func (c *Client) modifyApplicationWithContext(ctx context.Context, action string, application *Application, columns []string) (*Response, bool, error) {
 	queryMap := map[string]string{
 		"id": fmt.Sprintf("%s/%s", application.Owner, application.Name),
 	}
@@ -254,7 +319,7 @@ func (c *Client) modifyApplication(action string, application *Application, colu
 		return nil, false, err
 	}
 
-	resp, err := c.DoPost(action, queryMap, postBytes, false, false)
+	resp, err := c.doPostWithContext(ctx, action, queryMap, postBytes, false, false)
 	if err != nil {
 		return nil, false, err
 	}
@@ -315,10 +380,20 @@ func (c *Client) modifySession(action string, session *Session, columns []string
 // modifyUser is an encapsulation of user CUD(Create, Update, Delete) operations.
 // possible actions are `add-user`, `update-user`, `delete-user`,
 func (c *Client) modifyUser(action string, user *User, columns []string) (*Response, bool, error) {
-	return c.modifyUserById(action, user.GetId(), user, columns)
+	return c.modifyUserByIdWithContext(context.Background(), action, user.GetId(), user, columns)
+}
+
+// modifyUserWithContext is modifyUser, with the request canceled when ctx is done.
+func (c *Client) modifyUserWithContext(ctx context.Context, action string, user *User, columns []string) (*Response, bool, error) {
+	return c.modifyUserByIdWithContext(ctx, action, user.GetId(), user, columns)
 }
 
 func (c *Client) modifyUserById(action string, id string, user *User, columns []string) (*Response, bool, error) {
+	return c.modifyUserByIdWithContext(context.Background(), action, id, user, columns)
+}
+
+// modifyUserByIdWithContext is modifyUserById, with the request canceled when ctx is done.
+func (c *Client) modifyUserByIdWithContext(ctx context.Context, action string, id string, user *User, columns []string) (*Response, bool, error) {
 	queryMap := map[string]string{
 		"id": id,
 	}
@@ -333,7 +408,7 @@ func (c *Client) modifyUserById(action string, id string, user *User, columns []
 		return nil, false, err
 	}
 
-	resp, err := c.DoPost(action, queryMap, postBytes, false, false)
+	resp, err := c.doPostWithContext(ctx, action, queryMap, postBytes, false, false)
 	if err != nil {
 		return nil, false, err
 	}
@@ -394,6 +469,11 @@ func (c *Client) modifyRole(action string, role *Role, columns []string) (*Respo
 // modifyCert is an encapsulation of cert CUD(Create, Update, Delete) operations.
 // possible actions are `add-cert`, `update-cert`, `delete-cert`,
 func (c *Client) modifyCert(action string, cert *Cert, columns []string) (*Response, bool, error) {
+	return c.modifyCertWithContext(context.Background(), action, cert, columns)
+}
+
+// modifyCertWithContext is modifyCert, with the request canceled when ctx is done.
+func (c *Client) modifyCertWithContext(ctx context.Context, action string, cert *Cert, columns []string) (*Response, bool, error) {
 	queryMap := map[string]string{
 		"id": fmt.Sprintf("%s/%s", cert.Owner, cert.Name),
 	}
@@ -408,7 +488,7 @@ func (c *Client) modifyCert(action string, cert *Cert, columns []string) (*Respo
 		return nil, false, err
 	}
 
-	resp, err := c.DoPost(action, queryMap, postBytes, false, false)
+	resp, err := c.doPostWithContext(ctx, action, queryMap, postBytes, false, false)
 	if err != nil {
 		return nil, false, err
 	}
@@ -418,6 +498,11 @@ func (c *Client) modifyCert(action string, cert *Cert, columns []string) (*Respo
 
 // modifyEnforcer is an encapsulation of cert CUD(Create, Update, Delete) operations.
 func (c *Client) modifyEnforcer(action string, enforcer *Enforcer, columns []string) (*Response, bool, error) {
+	return c.modifyEnforcerWithContext(context.Background(), action, enforcer, columns)
+}
+
+// modifyEnforcerWithContext is modifyEnforcer, with the request canceled when ctx is done.
+func (c *Client) modifyEnforcerWithContext(ctx context.Context, action string, enforcer *Enforcer, columns []string) (*Response, bool, error) {
 	queryMap := map[string]string{
 		"id": fmt.Sprintf("%s/%s", enforcer.Owner, enforcer.Name),
 	}
@@ -432,7 +517,7 @@ func (c *Client) modifyEnforcer(action string, enforcer *Enforcer, columns []str
 		return nil, false, err
 	}
 
-	resp, err := c.DoPost(action, queryMap, postBytes, false, false)
+	resp, err := c.doPostWithContext(ctx, action, queryMap, postBytes, false, false)
 	if err != nil {
 		return nil, false, err
 	}
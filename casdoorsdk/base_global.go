@@ -14,7 +14,10 @@
 
 package casdoorsdk
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 // DoGetResponse is a general function to get response from param url through HTTP Get method.
 func DoGetResponse(url string) (*Response, error) {
@@ -39,3 +42,8 @@ func DoPost(action string, queryMap map[string]string, postBytes []byte, isForm,
 func DoPostBytesRaw(url string, contentType string, body io.Reader) ([]byte, error) {
 	return globalClient.DoPostBytesRaw(url, contentType, body)
 }
+
+// DoPostBytesRawWithContext is DoPostBytesRaw, with the request canceled when ctx is done.
+func DoPostBytesRawWithContext(ctx context.Context, url string, contentType string, body io.Reader) ([]byte, error) {
+	return globalClient.DoPostBytesRawWithContext(ctx, url, contentType, body)
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	defaultBatchEnforceChunkSize   = 1000
+	defaultBatchEnforceConcurrency = 4
+)
+
+type BatchEnforceOptions struct {
+	// ChunkSize is the number of requests sent to Casdoor at once, it defaults to 1000.
+	ChunkSize int
+	// Concurrency is the number of chunks enforced at the same time, it defaults to 4.
+	Concurrency int
+}
+
+// BatchEnforceChunk is the outcome of a chunk of requests of BatchEnforceStream.
+type BatchEnforceChunk struct {
+	// Offset is the index of the first request of the chunk in the input.
+	Offset   int
+	Requests []CasbinRequest
+	// Allowed has one decision per request, true if any of the matched permissions allows it.
+	Allowed []bool
+	// Results are the results of BatchEnforce for the chunk, one row per matched permission.
+	Results [][]bool
+	// Err is the error of the chunk, the other chunks are still enforced.
+	Err error
+}
+
+// BatchEnforceStream enforces the requests read from the channel in chunks, several chunks at a time,
+// and sends the chunks to the returned channel in the order of the requests. The returned channel is
+// closed once the requests channel is closed and every chunk has been sent, or when ctx is done,
+// which is how to stop early. The decision cache is not used.
+func (c *Client) BatchEnforceStream(ctx context.Context, permissionId, modelId, resourceId string, casbinRequests <-chan CasbinRequest, options BatchEnforceOptions) <-chan *BatchEnforceChunk {
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = defaultBatchEnforceChunkSize
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultBatchEnforceConcurrency
+	}
+
+	// each chunk gets its own result channel, queued in order, the queue and the
+	// semaphore bound the number of chunks in flight
+	pending := make(chan chan *BatchEnforceChunk, options.Concurrency)
+	semaphore := make(chan struct{}, options.Concurrency)
+	res := make(chan *BatchEnforceChunk)
+
+	go func() {
+		defer close(pending)
+
+		offset := 0
+		for {
+			requests, ok := readChunk(ctx, casbinRequests, options.ChunkSize)
+			if len(requests) != 0 {
+				select {
+				case semaphore <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+
+				result := make(chan *BatchEnforceChunk, 1)
+				select {
+				case pending <- result:
+				case <-ctx.Done():
+					return
+				}
+				go func(chunk *BatchEnforceChunk) {
+					defer func() { <-semaphore }()
+
+					chunk.Results, chunk.Err = c.batchEnforce(ctx, permissionId, modelId, resourceId, chunk.Requests)
+					if chunk.Err == nil {
+						chunk.Allowed, chunk.Err = getChunkDecisions(chunk.Results, len(chunk.Requests))
+					}
+					result <- chunk
+				}(&BatchEnforceChunk{Offset: offset, Requests: requests})
+				offset += len(requests)
+			}
+			if !ok {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(res)
+
+		for result := range pending {
+			chunk := <-result
+			select {
+			case res <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res
+}
+
+// SliceToRequestChannel returns a closed channel holding the requests, to stream requests already in memory.
+func SliceToRequestChannel(casbinRequests []CasbinRequest) <-chan CasbinRequest {
+	res := make(chan CasbinRequest, len(casbinRequests))
+	for _, casbinRequest := range casbinRequests {
+		res <- casbinRequest
+	}
+	close(res)
+	return res
+}
+
+// readChunk reads up to size requests, ok is false once the channel is closed or ctx is done.
+func readChunk(ctx context.Context, casbinRequests <-chan CasbinRequest, size int) ([]CasbinRequest, bool) {
+	var res []CasbinRequest
+	for len(res) < size {
+		select {
+		case casbinRequest, ok := <-casbinRequests:
+			if !ok {
+				return res, false
+			}
+			res = append(res, casbinRequest)
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// getChunkDecisions combines the rows of results of the matched permissions into one decision per request.
+func getChunkDecisions(results [][]bool, count int) ([]bool, error) {
+	res := make([]bool, count)
+	for _, row := range results {
+		if len(row) != count {
+			return nil, fmt.Errorf("got %d results for %d requests", len(row), count)
+		}
+		for i, isAllow := range row {
+			res[i] = res[i] || isAllow
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchEnforceStream(t *testing.T) {
+	// requests on even objects are allowed, a chunk containing object 42 fails
+	var inFlight, maxInFlight int64
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			max := atomic.LoadInt64(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(rand.Intn(10)) * time.Millisecond)
+
+		var requests [][]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&requests)
+		row := []bool{}
+		for _, request := range requests {
+			if request[1] == "data42" {
+				return nil, errors.New("invalid request")
+			}
+			var i int
+			_, _ = fmt.Sscanf(request[1].(string), "data%d", &i)
+			row = append(row, i%2 == 0)
+		}
+		return [][]bool{row}, nil
+	})
+
+	var requests []CasbinRequest
+	for i := 0; i < 95; i++ {
+		requests = append(requests, CasbinRequest{"alice", fmt.Sprintf("data%d", i), "read"})
+	}
+
+	options := BatchEnforceOptions{ChunkSize: 10, Concurrency: 3}
+	offset := 0
+	for chunk := range client.BatchEnforceStream(context.Background(), "built-in/permission", "", "", SliceToRequestChannel(requests), options) {
+		if chunk.Offset != offset {
+			t.Fatalf("Expected chunk at offset %d, got %d", offset, chunk.Offset)
+		}
+		offset += len(chunk.Requests)
+
+		if chunk.Offset == 40 {
+			if chunk.Err == nil {
+				t.Errorf("Expected the chunk of data42 to fail")
+			}
+			continue
+		}
+		if chunk.Err != nil {
+			t.Fatalf("Unexpected error at offset %d: %v", chunk.Offset, chunk.Err)
+		}
+		for i, allowed := range chunk.Allowed {
+			if allowed != ((chunk.Offset+i)%2 == 0) {
+				t.Errorf("Unexpected decision %v for request %v", allowed, chunk.Requests[i])
+			}
+		}
+	}
+
+	if offset != len(requests) {
+		t.Errorf("Expected %d requests to be enforced, got %d", len(requests), offset)
+	}
+	if maxInFlight > int64(options.Concurrency) {
+		t.Errorf("Expected at most %d chunks in flight, got %d", options.Concurrency, maxInFlight)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package casbinadapter implements a Casbin adapter storing the policies of a Casdoor enforcer,
+// so that casbin.NewEnforcer(model, casbinadapter.NewAdapter(client, "enforcer-name")) works out of the box.
+package casbinadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+var (
+	_ persist.Adapter          = (*Adapter)(nil)
+	_ persist.BatchAdapter     = (*Adapter)(nil)
+	_ persist.FilteredAdapter  = (*Adapter)(nil)
+	_ persist.UpdatableAdapter = (*Adapter)(nil)
+)
+
+// maxRuleValues is the number of values of a Casdoor policy rule, V0 to V5.
+const maxRuleValues = 6
+
+// Adapter loads and saves policy rules through the Casdoor API, for a given Casdoor enforcer.
+// Casdoor has no batch or transactional policy API, so batch operations and SavePolicy
+// are performed rule by rule and may be applied partially when an error occurs.
+// A change Casdoor does not apply, e.g. removing a rule it does not store, is an error.
+type Adapter struct {
+	client   *casdoorsdk.Client
+	enforcer *casdoorsdk.Enforcer
+	filtered bool
+}
+
+// Filter selects the rules loaded by LoadFilteredPolicy, an empty field matches every value.
+type Filter struct {
+	Ptype []string
+	V0    []string
+	V1    []string
+	V2    []string
+	V3    []string
+	V4    []string
+	V5    []string
+}
+
+// NewAdapter returns an adapter for the enforcer named enforcerName in the client's organization.
+func NewAdapter(client *casdoorsdk.Client, enforcerName string) *Adapter {
+	return &Adapter{
+		client: client,
+		enforcer: &casdoorsdk.Enforcer{
+			Owner: client.OrganizationName,
+			Name:  enforcerName,
+		},
+	}
+}
+
+// NewAdapterFromCasdoorAdapter returns an adapter for the policies stored by the Casdoor adapter named
+// adapterName in the client's organization. Casdoor only serves policies through enforcers, so the
+// policies are read and written through an enforcer using that adapter, which must exist.
+func NewAdapterFromCasdoorAdapter(client *casdoorsdk.Client, adapterName string) (*Adapter, error) {
+	enforcers, err := client.GetEnforcers()
+	if err != nil {
+		return nil, err
+	}
+
+	adapterId := fmt.Sprintf("%s/%s", client.OrganizationName, adapterName)
+	for _, enforcer := range enforcers {
+		if enforcer.Adapter == adapterId || enforcer.Adapter == adapterName {
+			return &Adapter{client: client, enforcer: enforcer}, nil
+		}
+	}
+	return nil, fmt.Errorf("no enforcer uses the adapter %s", adapterId)
+}
+
+// LoadPolicy loads all policy rules of the enforcer.
+func (a *Adapter) LoadPolicy(model model.Model) error {
+	a.filtered = false
+	return a.loadPolicy(model, nil)
+}
+
+// LoadFilteredPolicy loads the policy rules matching filter, which must be a Filter or *Filter.
+func (a *Adapter) LoadFilteredPolicy(model model.Model, filter interface{}) error {
+	var f *Filter
+	switch v := filter.(type) {
+	case nil:
+	case Filter:
+		f = &v
+	case *Filter:
+		f = v
+	default:
+		return fmt.Errorf("invalid filter type %T", filter)
+	}
+
+	err := a.loadPolicy(model, f)
+	if err != nil {
+		return err
+	}
+
+	a.filtered = f != nil
+	return nil
+}
+
+// IsFiltered returns true if the loaded policy has been filtered.
+func (a *Adapter) IsFiltered() bool {
+	return a.filtered
+}
+
+func (a *Adapter) loadPolicy(model model.Model, filter *Filter) error {
+	rules, err := a.client.GetPolicies(context.Background(), a.enforcer)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if filter != nil && !filter.match(rule) {
+			continue
+		}
+
+		err = persist.LoadPolicyArray(rule.ToArray(), model)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SavePolicy replaces all policy rules of the enforcer by the ones of model.
+func (a *Adapter) SavePolicy(model model.Model) error {
+	if a.filtered {
+		return errors.New("cannot save a filtered policy")
+	}
+
+	rules, err := a.client.GetPolicies(context.Background(), a.enforcer)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		err = a.removeRule(rule)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sec := range []string{"p", "g"} {
+		for ptype, assertion := range model[sec] {
+			for _, values := range assertion.Policy {
+				err = a.AddPolicy(sec, ptype, values)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// AddPolicy adds a policy rule to the enforcer.
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	r, err := toRule(ptype, rule)
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.client.AddPolicy(context.Background(), a.enforcer, r)
+	if err != nil {
+		return err
+	}
+	if !affected {
+		return fmt.Errorf("policy %v of %s was not added", rule, ptype)
+	}
+	return nil
+}
+
+// AddPolicies adds policy rules to the enforcer.
+func (a *Adapter) AddPolicies(sec string, ptype string, rules [][]string) error {
+	for _, rule := range rules {
+		err := a.AddPolicy(sec, ptype, rule)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemovePolicy removes a policy rule from the enforcer.
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	r, err := toRule(ptype, rule)
+	if err != nil {
+		return err
+	}
+	return a.removeRule(r)
+}
+
+// RemovePolicies removes policy rules from the enforcer.
+func (a *Adapter) RemovePolicies(sec string, ptype string, rules [][]string) error {
+	for _, rule := range rules {
+		err := a.RemovePolicy(sec, ptype, rule)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveFilteredPolicy removes the policy rules of ptype whose values from fieldIndex on match fieldValues,
+// an empty field value matches every value.
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	_, err := a.removeFilteredPolicy(ptype, fieldIndex, fieldValues)
+	return err
+}
+
+// UpdatePolicy replaces a policy rule of the enforcer.
+func (a *Adapter) UpdatePolicy(sec string, ptype string, oldRule, newRule []string) error {
+	oldR, err := toRule(ptype, oldRule)
+	if err != nil {
+		return err
+	}
+	newR, err := toRule(ptype, newRule)
+	if err != nil {
+		return err
+	}
+
+	affected, err := a.client.UpdatePolicy(context.Background(), a.enforcer, oldR, newR)
+	if err != nil {
+		return err
+	}
+	if !affected {
+		return fmt.Errorf("policy %v of %s was not updated", oldRule, ptype)
+	}
+	return nil
+}
+
+// UpdatePolicies replaces policy rules of the enforcer, oldRules[i] by newRules[i].
+func (a *Adapter) UpdatePolicies(sec string, ptype string, oldRules, newRules [][]string) error {
+	if len(oldRules) != len(newRules) {
+		return fmt.Errorf("got %d old rules and %d new rules", len(oldRules), len(newRules))
+	}
+
+	for i := range oldRules {
+		err := a.UpdatePolicy(sec, ptype, oldRules[i], newRules[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateFilteredPolicies removes the policy rules matched like in RemoveFilteredPolicy, adds newRules,
+// and returns the removed rules.
+func (a *Adapter) UpdateFilteredPolicies(sec string, ptype string, newRules [][]string, fieldIndex int, fieldValues ...string) ([][]string, error) {
+	for _, rule := range newRules {
+		_, err := toRule(ptype, rule)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	oldRules, err := a.removeFilteredPolicy(ptype, fieldIndex, fieldValues)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.AddPolicies(sec, ptype, newRules)
+	if err != nil {
+		return nil, err
+	}
+	return oldRules, nil
+}
+
+func (a *Adapter) removeFilteredPolicy(ptype string, fieldIndex int, fieldValues []string) ([][]string, error) {
+	rules, err := a.client.GetPolicies(context.Background(), a.enforcer)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed [][]string
+	for _, rule := range rules {
+		if rule.Ptype != ptype {
+			continue
+		}
+
+		values := rule.ToArray()[1:]
+		matched := true
+		for i, fieldValue := range fieldValues {
+			index := fieldIndex + i
+			if fieldValue == "" {
+				continue
+			}
+			if index >= len(values) || values[index] != fieldValue {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		err = a.removeRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		removed = append(removed, values)
+	}
+	return removed, nil
+}
+
+func (a *Adapter) removeRule(rule *casdoorsdk.PermissionRule) error {
+	affected, err := a.client.RemovePolicy(context.Background(), a.enforcer, rule)
+	if err != nil {
+		return err
+	}
+	if !affected {
+		return fmt.Errorf("policy %v was not removed", rule.ToArray())
+	}
+	return nil
+}
+
+func (f *Filter) match(rule *casdoorsdk.PermissionRule) bool {
+	fields := []struct {
+		allowed []string
+		value   string
+	}{
+		{f.Ptype, rule.Ptype},
+		{f.V0, rule.V0},
+		{f.V1, rule.V1},
+		{f.V2, rule.V2},
+		{f.V3, rule.V3},
+		{f.V4, rule.V4},
+		{f.V5, rule.V5},
+	}
+
+	for _, field := range fields {
+		if len(field.allowed) == 0 {
+			continue
+		}
+
+		found := false
+		for _, allowed := range field.allowed {
+			if allowed == field.value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// toRule returns the Casdoor rule of ptype holding values, which cannot hold more than 6 of them.
+func toRule(ptype string, values []string) (*casdoorsdk.PermissionRule, error) {
+	if len(values) > maxRuleValues {
+		return nil, fmt.Errorf("policy %v of %s has %d values, Casdoor stores at most %d", values, ptype, len(values), maxRuleValues)
+	}
+
+	v := make([]string, maxRuleValues)
+	copy(v, values)
+	return &casdoorsdk.PermissionRule{Ptype: ptype, V0: v[0], V1: v[1], V2: v[2], V3: v[3], V4: v[4], V5: v[5]}, nil
+}
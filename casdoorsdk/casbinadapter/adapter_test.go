@@ -0,0 +1,216 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casbinadapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk/casdoortest"
+)
+
+const testModelText = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act`
+
+// fakeEnforcerServer stores the policies of a single enforcer in memory, like Casdoor's policy API.
+type fakeEnforcerServer struct {
+	mutex sync.Mutex
+	rules []map[string]string
+}
+
+func (s *fakeEnforcerServer) handle(r *http.Request) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch r.URL.Path {
+	case "/api/get-enforcers":
+		return []*casdoorsdk.Enforcer{{Owner: "built-in", Name: "enforcer-rbac", Adapter: "built-in/adapter-db"}}, nil
+	case "/api/get-policies":
+		if r.URL.Query().Get("id") != "built-in/enforcer-rbac" {
+			return nil, fmt.Errorf("unexpected enforcer %s", r.URL.Query().Get("id"))
+		}
+		return s.rules, nil
+	case "/api/add-policy":
+		var rule map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		s.rules = append(s.rules, rule)
+		return "Affected", nil
+	case "/api/remove-policy":
+		var rule map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&rule)
+		for i, existing := range s.rules {
+			if sameRule(existing, rule) {
+				s.rules = append(s.rules[:i], s.rules[i+1:]...)
+				return "Affected", nil
+			}
+		}
+		return "Unaffected", nil
+	case "/api/update-policy":
+		var rules []map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&rules)
+		for i, existing := range s.rules {
+			if sameRule(existing, rules[0]) {
+				s.rules[i] = rules[1]
+				return "Affected", nil
+			}
+		}
+		return "Unaffected", nil
+	}
+	return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+}
+
+func sameRule(a, b map[string]string) bool {
+	for _, key := range []string{"ptype", "v0", "v1", "v2", "v3", "v4", "v5"} {
+		if a[key] != b[key] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAdapter(t *testing.T) {
+	server := &fakeEnforcerServer{}
+	_, client := casdoortest.NewServer(t, server.handle)
+	adapter := NewAdapter(client, "enforcer-rbac")
+
+	m, err := model.NewModelFromString(testModelText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enforcer, err := casbin.NewEnforcer(m, adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = enforcer.AddPolicy("admin", "data1", "read"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = enforcer.AddPolicy("bob", "data2", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = enforcer.AddGroupingPolicy("alice", "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = enforcer.RemovePolicy("bob", "data2", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = enforcer.AddPolicy("carol", "data3", "read"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = enforcer.UpdatePolicy([]string{"carol", "data3", "read"}, []string{"carol", "data3", "write"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a fresh enforcer only sees what has been stored in Casdoor
+	enforcer, err = casbin.NewEnforcer(m, adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		request  []interface{}
+		expected bool
+	}{
+		{request: []interface{}{"alice", "data1", "read"}, expected: true},
+		{request: []interface{}{"alice", "data1", "write"}, expected: false},
+		{request: []interface{}{"bob", "data2", "write"}, expected: false},
+		{request: []interface{}{"carol", "data3", "read"}, expected: false},
+		{request: []interface{}{"carol", "data3", "write"}, expected: true},
+	}
+	for _, tc := range testCases {
+		allowed, err := enforcer.Enforce(tc.request...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != tc.expected {
+			t.Errorf("For request %v, expected %v, but got %v", tc.request, tc.expected, allowed)
+		}
+	}
+
+	err = enforcer.LoadFilteredPolicy(&Filter{Ptype: []string{"g"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policies, _ := enforcer.GetPolicy()
+	groupingPolicies, _ := enforcer.GetGroupingPolicy()
+	if !adapter.IsFiltered() || len(policies) != 0 || len(groupingPolicies) != 1 {
+		t.Errorf("Unexpected filtered policy %v and grouping policy %v", policies, groupingPolicies)
+	}
+	if err = enforcer.SavePolicy(); err == nil {
+		t.Errorf("Expected saving a filtered policy to fail")
+	}
+}
+
+func TestAdapterErrors(t *testing.T) {
+	server := &fakeEnforcerServer{}
+	_, client := casdoortest.NewServer(t, server.handle)
+	adapter := NewAdapter(client, "enforcer-rbac")
+
+	if err := adapter.RemovePolicy("p", "p", []string{"alice", "data1", "read"}); err == nil {
+		t.Errorf("Expected removing a rule Casdoor does not store to fail")
+	}
+	if err := adapter.UpdatePolicy("p", "p", []string{"alice", "data1", "read"}, []string{"alice", "data1", "write"}); err == nil {
+		t.Errorf("Expected updating a rule Casdoor does not store to fail")
+	}
+	if err := adapter.AddPolicy("p", "p", []string{"a", "b", "c", "d", "e", "f", "g"}); err == nil {
+		t.Errorf("Expected a rule of 7 values to be rejected")
+	}
+	if len(server.rules) != 0 {
+		t.Errorf("Expected nothing to be stored, got %v", server.rules)
+	}
+}
+
+func TestNewAdapterFromCasdoorAdapter(t *testing.T) {
+	server := &fakeEnforcerServer{rules: []map[string]string{{"ptype": "p", "v0": "alice", "v1": "data1", "v2": "read"}}}
+	_, client := casdoortest.NewServer(t, server.handle)
+
+	adapter, err := NewAdapterFromCasdoorAdapter(client, "adapter-db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := model.NewModelFromString(testModelText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enforcer, err := casbin.NewEnforcer(m, adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowed, _ := enforcer.Enforce("alice", "data1", "read"); !allowed {
+		t.Errorf("Expected the policies of the enforcer using the adapter to be loaded")
+	}
+
+	if _, err = NewAdapterFromCasdoorAdapter(client, "adapter-missing"); err == nil {
+		t.Errorf("Expected an error for an adapter no enforcer uses")
+	}
+}
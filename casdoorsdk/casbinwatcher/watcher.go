@@ -0,0 +1,350 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package casbinwatcher implements a Casbin watcher reloading the policies of every subscribed
+// enforcer when permissions, roles or enforcers change in Casdoor, e.g. enforcer.SetWatcher(watcher).
+package casbinwatcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+var _ persist.Watcher = (*Watcher)(nil)
+
+const (
+	SourcePoll    = "poll"
+	SourceWebhook = "webhook"
+	SourceUpdate  = "update"
+)
+
+// SecretHeader is the header carrying Options.WebhookSecret in the webhook requests,
+// to be added to the headers of the Casdoor webhook.
+const SecretHeader = "X-Casdoor-Webhook-Secret"
+
+// watchedObjects are the kinds of Casdoor objects whose changes affect the policies,
+// webhook records are matched against them by action, e.g. "update-permission".
+var watchedObjects = []string{"permission", "role", "enforcer", "policy", "model"}
+
+type Options struct {
+	// PollInterval compares the permissions, roles and enforcers with their previous state periodically,
+	// 0 disables polling, e.g. when changes are only received through ServeHTTP.
+	PollInterval time.Duration
+	// Debounce waits for changes to settle for this long before reloading the enforcers,
+	// so that a burst of edits triggers a single reload.
+	Debounce time.Duration
+	// EnforcerNames are the enforcers whose policies are polled too,
+	// since editing a policy does not change the Enforcer object itself.
+	EnforcerNames []string
+	// WebhookSecret must be sent in the SecretHeader of the webhook requests, ServeHTTP rejects
+	// every request when it is empty.
+	WebhookSecret string
+	// OnChange is called after the enforcers have been reloaded, and when polling fails.
+	OnChange func(event *Event)
+}
+
+// Event describes a change detected by the watcher, or a polling error when Err is not nil.
+type Event struct {
+	// Source is SourcePoll, SourceWebhook or SourceUpdate.
+	Source string
+	// Objects are the kinds of objects that changed, e.g. "permission" or "policy".
+	Objects []string
+	// Callbacks is the number of subscribed enforcers that have been notified.
+	Callbacks int
+	Time      time.Time
+	Err       error
+}
+
+// Watcher detects changes of the Casdoor objects the policies are built from, by polling and/or
+// by receiving Casdoor webhooks, and calls the update callback of every subscribed enforcer.
+type Watcher struct {
+	client  *casdoorsdk.Client
+	options Options
+
+	mutex     sync.Mutex
+	callbacks []func(string)
+	hashes    map[string]string
+	pending   *Event
+	timer     *time.Timer
+	closed    bool
+
+	// fireMutex serializes the reloads, so that the callbacks of a change are not run
+	// concurrently with the ones of the next change.
+	fireMutex sync.Mutex
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewWatcher returns a watcher, which starts polling if options.PollInterval is set.
+// The current state is fetched first so that only later changes are reported.
+func NewWatcher(client *casdoorsdk.Client, options Options) (*Watcher, error) {
+	w := &Watcher{
+		client:  client,
+		options: options,
+		stop:    make(chan struct{}),
+	}
+
+	if options.PollInterval > 0 {
+		hashes, err := w.getHashes()
+		if err != nil {
+			return nil, err
+		}
+		w.hashes = hashes
+
+		go w.run()
+	}
+	return w, nil
+}
+
+// SetUpdateCallback subscribes a callback, enforcer.SetWatcher subscribes enforcer.LoadPolicy.
+// Unlike most watchers, several enforcers can subscribe to the same watcher.
+func (w *Watcher) SetUpdateCallback(callback func(string)) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.callbacks = append(w.callbacks, callback)
+	return nil
+}
+
+// Update is called by an enforcer after it changed the policies, every subscribed enforcer is reloaded,
+// including the one calling Update, as Casbin does not tell which enforcer a callback belongs to.
+// Other instances detect the change themselves, since the policies are stored in Casdoor.
+func (w *Watcher) Update() error {
+	w.trigger(SourceUpdate, []string{"policy"})
+	return nil
+}
+
+// Close stops polling, the callbacks are not called any more.
+func (w *Watcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		w.closed = true
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+	})
+}
+
+// ServeHTTP lets the watcher be registered as the endpoint of a Casdoor webhook, whose headers must
+// include the SecretHeader holding Options.WebhookSecret. Records of actions on other objects are ignored,
+// bodies that are not records are treated as a change.
+func (w *Watcher) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	secret := r.Header.Get(SecretHeader)
+	if w.options.WebhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(w.options.WebhookSecret)) != 1 {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var record casdoorsdk.Record
+	err := json.NewDecoder(r.Body).Decode(&record)
+	if err != nil || record.Action == "" {
+		w.trigger(SourceWebhook, nil)
+	} else if objects := getObjects(record.Action); len(objects) != 0 {
+		w.trigger(SourceWebhook, objects)
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+// Check polls Casdoor once, and schedules a reload if anything changed since the previous check.
+func (w *Watcher) Check() error {
+	hashes, err := w.getHashes()
+	if err != nil {
+		w.emit(&Event{Source: SourcePoll, Time: time.Now(), Err: err})
+		return err
+	}
+
+	w.mutex.Lock()
+	var objects []string
+	for object, hash := range hashes {
+		if w.hashes[object] != hash {
+			objects = append(objects, object)
+		}
+	}
+	w.hashes = hashes
+	w.mutex.Unlock()
+
+	if len(objects) != 0 {
+		sort.Strings(objects)
+		w.trigger(SourcePoll, objects)
+	}
+	return nil
+}
+
+func (w *Watcher) run() {
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			_ = w.Check()
+		}
+	}
+}
+
+// trigger records a change and (re)starts the debounce timer, the changes are merged until it fires.
+func (w *Watcher) trigger(source string, objects []string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	if w.pending == nil {
+		w.pending = &Event{}
+	}
+	w.pending.Source = source
+	for _, object := range objects {
+		if !contains(w.pending.Objects, object) {
+			w.pending.Objects = append(w.pending.Objects, object)
+		}
+	}
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.options.Debounce, w.fire)
+}
+
+func (w *Watcher) fire() {
+	w.fireMutex.Lock()
+	defer w.fireMutex.Unlock()
+
+	w.mutex.Lock()
+	event := w.pending
+	w.pending = nil
+	callbacks := append([]func(string){}, w.callbacks...)
+	closed := w.closed
+	w.mutex.Unlock()
+
+	if event == nil || closed {
+		return
+	}
+
+	// the change is already known, so the next poll must not report it again
+	if event.Source != SourcePoll && w.options.PollInterval > 0 {
+		hashes, err := w.getHashes()
+		if err == nil {
+			w.mutex.Lock()
+			w.hashes = hashes
+			w.mutex.Unlock()
+		}
+	}
+
+	for _, callback := range callbacks {
+		callback(event.Source)
+	}
+
+	event.Callbacks = len(callbacks)
+	event.Time = time.Now()
+	w.emit(event)
+}
+
+func (w *Watcher) emit(event *Event) {
+	if w.options.OnChange != nil {
+		w.options.OnChange(event)
+	}
+}
+
+// getHashes returns a hash of the current state of each kind of watched object.
+func (w *Watcher) getHashes() (map[string]string, error) {
+	permissions, err := w.client.GetPermissions()
+	if err != nil {
+		return nil, err
+	}
+	roles, err := w.client.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+	enforcers, err := w.client.GetEnforcers()
+	if err != nil {
+		return nil, err
+	}
+
+	policies := map[string][]*casdoorsdk.PermissionRule{}
+	for _, enforcerName := range w.options.EnforcerNames {
+		policies[enforcerName], err = w.client.GetPolicies(context.Background(), &casdoorsdk.Enforcer{Name: enforcerName})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hashes := map[string]string{}
+	objects := map[string]interface{}{
+		"permission": permissions,
+		"role":       roles,
+		"enforcer":   enforcers,
+		"policy":     policies,
+	}
+	for object, value := range objects {
+		hashes[object], err = getHash(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+func getHash(value interface{}) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// getObjects returns the kinds of watched objects affected by a record action, e.g. "add-permission".
+func getObjects(action string) []string {
+	var objects []string
+	for _, object := range watchedObjects {
+		if strings.Contains(action, object) {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casbinwatcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk/casdoortest"
+)
+
+// fakeServer serves the objects polled by the watcher, the permissions can be edited by the test.
+type fakeServer struct {
+	mutex       sync.Mutex
+	permissions []*casdoorsdk.Permission
+}
+
+func (s *fakeServer) handle(r *http.Request) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if r.URL.Path == "/api/get-permissions" {
+		return s.permissions, nil
+	}
+	return []interface{}{}, nil
+}
+
+func (s *fakeServer) setPermissions(permissions ...*casdoorsdk.Permission) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.permissions = permissions
+}
+
+func TestWatcher(t *testing.T) {
+	server := &fakeServer{}
+	_, client := casdoortest.NewServer(t, server.handle)
+	events := make(chan *Event, 10)
+	watcher, err := NewWatcher(client, Options{
+		PollInterval:  time.Hour,
+		Debounce:      20 * time.Millisecond,
+		WebhookSecret: "secret",
+		OnChange:      func(event *Event) { events <- event },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	var mutex sync.Mutex
+	reloads := 0
+	for i := 0; i < 2; i++ {
+		_ = watcher.SetUpdateCallback(func(string) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			reloads++
+		})
+	}
+
+	if err = watcher.Check(); err != nil {
+		t.Fatal(err)
+	}
+	server.setPermissions(&casdoorsdk.Permission{Owner: "built-in", Name: "permission-1"})
+	if err = watcher.Check(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"action":"update-role"}`))
+		request.Header.Set(SecretHeader, "secret")
+		watcher.ServeHTTP(httptest.NewRecorder(), request)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"action":"update-user"}`))
+	request.Header.Set(SecretHeader, "secret")
+	watcher.ServeHTTP(httptest.NewRecorder(), request)
+	request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"action":"update-enforcer"}`))
+	request.Header.Set(SecretHeader, "wrong")
+	recorder := httptest.NewRecorder()
+	watcher.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected a request with a wrong secret to be rejected, got %d", recorder.Code)
+	}
+
+	select {
+	case event := <-events:
+		if event.Err != nil || event.Source != SourceWebhook || event.Callbacks != 2 ||
+			len(event.Objects) != 2 || event.Objects[0] != "permission" || event.Objects[1] != "role" {
+			t.Errorf("Unexpected event %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the change to be reported")
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("Expected the changes to be debounced, got extra event %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if reloads != 2 {
+		t.Errorf("Expected each subscribed enforcer to be reloaded once, got %d reloads", reloads)
+	}
+}
+
+func TestWatcherWithoutSecret(t *testing.T) {
+	server := &fakeServer{}
+	_, client := casdoortest.NewServer(t, server.handle)
+	watcher, err := NewWatcher(client, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"action":"update-role"}`))
+	recorder := httptest.NewRecorder()
+	watcher.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected webhooks to be rejected without a secret, got %d", recorder.Code)
+	}
+}
+
+func TestWatcherSerializesReloads(t *testing.T) {
+	server := &fakeServer{}
+	_, client := casdoortest.NewServer(t, server.handle)
+	watcher, err := NewWatcher(client, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	var mutex sync.Mutex
+	running, overlaps, reloads := 0, 0, 0
+	_ = watcher.SetUpdateCallback(func(string) {
+		mutex.Lock()
+		running++
+		if running > 1 {
+			overlaps++
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		reloads++
+		mutex.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = watcher.Update()
+			watcher.fire()
+		}()
+	}
+	wg.Wait()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if overlaps != 0 || reloads == 0 {
+		t.Errorf("Expected the reloads to run one at a time, got %d reloads and %d overlaps", reloads, overlaps)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package casdoortest mints Casdoor-compatible tokens locally and fakes the
+// Casdoor API, so that code using casdoorsdk can be tested without a Casdoor server.
+package casdoortest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TokenIssuer signs tokens the same way a Casdoor application with the given cert would.
+type TokenIssuer struct {
+	Issuer   string
+	ClientId string
+
+	cert       *casdoorsdk.Cert
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	x509Cert   *x509.Certificate
+}
+
+// TokenOptions controls the content of a minted token.
+type TokenOptions struct {
+	// TokenFormat is one of the casdoorsdk.TokenFormat* constants, "JWT" by default.
+	TokenFormat string
+	// ExpiresIn is the token lifetime, one hour by default. A negative value mints an expired token.
+	ExpiresIn time.Duration
+	TokenType string
+	Scope     string
+	Nonce     string
+	// TokenFields lists the user json fields put into a "JWT-Custom" token.
+	TokenFields []string
+	// Extra claims are added to "JWT-Custom" tokens as is.
+	Extra map[string]interface{}
+}
+
+// NewTokenIssuer generates a fresh key pair and self-signed certificate for algorithm,
+// which can be any algorithm supported by casdoorsdk.NewCert.
+func NewTokenIssuer(algorithm string) (*TokenIssuer, error) {
+	cert, err := casdoorsdk.NewCert(casdoorsdk.CertSpec{
+		Name:          "cert-test",
+		DisplayName:   "Test Cert",
+		Algorithm:     algorithm,
+		BitSize:       2048,
+		ExpireInYears: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Owner = "admin"
+	return NewTokenIssuerFromCert(cert)
+}
+
+// NewTokenIssuerFromCert signs with the private key of an existing cert, e.g. one exported from Casdoor.
+func NewTokenIssuerFromCert(cert *casdoorsdk.Cert) (*TokenIssuer, error) {
+	method := jwt.GetSigningMethod(cert.CryptoAlgorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported algorithm: %s", cert.CryptoAlgorithm)
+	}
+
+	privateKey, err := parsePrivateKey(cert.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	x509Cert, err := cert.ParseX509()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenIssuer{
+		Issuer:     "http://localhost:8000",
+		ClientId:   "test-client-id",
+		cert:       cert,
+		method:     method,
+		privateKey: privateKey,
+		x509Cert:   x509Cert,
+	}, nil
+}
+
+// Cert returns the cert, including its private key, the issuer signs with.
+func (i *TokenIssuer) Cert() *casdoorsdk.Cert {
+	return i.cert
+}
+
+// Certificate returns the PEM encoded certificate, suitable for AuthConfig.Certificate.
+func (i *TokenIssuer) Certificate() string {
+	return i.cert.Certificate
+}
+
+// NewClient returns a client that verifies the tokens of this issuer.
+func (i *TokenIssuer) NewClient(organizationName string, applicationName string) *casdoorsdk.Client {
+	return casdoorsdk.NewClient(i.Issuer, i.ClientId, "", i.Certificate(), organizationName, applicationName)
+}
+
+// Jwks returns the JSON Web Key Set document Casdoor would serve at /.well-known/jwks for this issuer.
+func (i *TokenIssuer) Jwks() ([]byte, error) {
+	key := map[string]interface{}{
+		"kid": i.cert.Name,
+		"use": "sig",
+		"alg": i.method.Alg(),
+		"x5c": []string{base64.StdEncoding.EncodeToString(i.x509Cert.Raw)},
+	}
+
+	switch publicKey := i.privateKey.Public().(type) {
+	case *rsa.PublicKey:
+		key["kty"] = "RSA"
+		key["n"] = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		key["e"] = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		key["kty"] = "EC"
+		key["crv"] = publicKey.Curve.Params().Name
+		key["x"] = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, size)))
+		key["y"] = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		key["kty"] = "OKP"
+		key["crv"] = "Ed25519"
+		key["x"] = base64.RawURLEncoding.EncodeToString(publicKey)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"keys": []interface{}{key},
+	})
+}
+
+// MintToken signs a token for user in the requested token format.
+func (i *TokenIssuer) MintToken(user *casdoorsdk.User, options TokenOptions) (string, error) {
+	expiresIn := options.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = time.Hour
+	}
+	tokenType := options.TokenType
+	if tokenType == "" {
+		tokenType = "access-token"
+	}
+
+	now := time.Now()
+	registeredClaims := jwt.RegisteredClaims{
+		Issuer:    i.Issuer,
+		Subject:   user.Id,
+		Audience:  []string{i.ClientId},
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiresIn)),
+		NotBefore: jwt.NewNumericDate(now.Add(-time.Minute)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        fmt.Sprintf("%d", now.UnixNano()),
+	}
+
+	var claims jwt.Claims
+	switch options.TokenFormat {
+	case "", casdoorsdk.TokenFormatJwt:
+		claims = &casdoorsdk.Claims{
+			User:             *user,
+			RegisteredClaims: registeredClaims,
+		}
+	case casdoorsdk.TokenFormatJwtEmpty:
+		claims = &casdoorsdk.StandardClaims{
+			Owner:            user.Owner,
+			Name:             user.Name,
+			TokenType:        tokenType,
+			Nonce:            options.Nonce,
+			Scope:            options.Scope,
+			RegisteredClaims: registeredClaims,
+		}
+	case casdoorsdk.TokenFormatJwtStandard:
+		claims = &casdoorsdk.StandardClaims{
+			Owner:            user.Owner,
+			Name:             user.Name,
+			Id:               user.Id,
+			DisplayName:      user.DisplayName,
+			Avatar:           user.Avatar,
+			Email:            user.Email,
+			EmailVerified:    user.EmailVerified,
+			Phone:            user.Phone,
+			PhoneNumber:      user.Phone,
+			Gender:           user.Gender,
+			Tag:              user.Tag,
+			TokenType:        tokenType,
+			Nonce:            options.Nonce,
+			Scope:            options.Scope,
+			Azp:              i.ClientId,
+			RegisteredClaims: registeredClaims,
+		}
+	case casdoorsdk.TokenFormatJwtCustom:
+		customClaims, err := i.getCustomClaims(user, registeredClaims, tokenType, options)
+		if err != nil {
+			return "", err
+		}
+		claims = customClaims
+	default:
+		return "", fmt.Errorf("unsupported token format: %s", options.TokenFormat)
+	}
+
+	token := jwt.NewWithClaims(i.method, claims)
+	token.Header["kid"] = i.cert.Name
+	return token.SignedString(i.privateKey)
+}
+
+func (i *TokenIssuer) getCustomClaims(user *casdoorsdk.User, registeredClaims jwt.RegisteredClaims, tokenType string, options TokenOptions) (casdoorsdk.CustomClaims, error) {
+	claims := casdoorsdk.CustomClaims{}
+	err := convert(registeredClaims, &claims)
+	if err != nil {
+		return nil, err
+	}
+
+	userMap := map[string]interface{}{}
+	err = convert(user, &userMap)
+	if err != nil {
+		return nil, err
+	}
+
+	claims["owner"] = user.Owner
+	claims["name"] = user.Name
+	claims["tokenType"] = tokenType
+	if options.Nonce != "" {
+		claims["nonce"] = options.Nonce
+	}
+	if options.Scope != "" {
+		claims["scope"] = options.Scope
+	}
+	for _, field := range options.TokenFields {
+		claims[field] = userMap[field]
+	}
+	for k, v := range options.Extra {
+		claims[k] = v
+	}
+	return claims, nil
+}
+
+func convert(from interface{}, to interface{}) error {
+	bytes, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, to)
+}
+
+func parsePrivateKey(privateKeyPem string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, errors.New("private key must be PEM encoded")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", privateKey)
+	}
+	return signer, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoortest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+func TestMintToken(t *testing.T) {
+	user := &casdoorsdk.User{
+		Owner:      "built-in",
+		Name:       "alice",
+		Id:         "b5b9c3a4-1d2e-4f7a-9c1b-0a3f5e6d7c8b",
+		Email:      "alice@example.com",
+		Properties: map[string]string{"team": "infra"},
+	}
+
+	for _, algorithm := range []string{"RS256", "ES256", "EdDSA"} {
+		issuer, err := NewTokenIssuer(algorithm)
+		if err != nil {
+			t.Fatalf("Failed to create %s issuer: %v", algorithm, err)
+		}
+		client := issuer.NewClient("built-in", "app-built-in")
+
+		token, err := issuer.MintToken(user, TokenOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, err := client.ParseJwtToken(token)
+		if err != nil {
+			t.Fatalf("Failed to verify %s token: %v", algorithm, err)
+		}
+		if claims.Name != user.Name || claims.Email != user.Email || claims.Properties["team"] != "infra" {
+			t.Errorf("Unexpected %s claims %+v", algorithm, claims.User)
+		}
+
+		token, err = issuer.MintToken(user, TokenOptions{TokenFormat: casdoorsdk.TokenFormatJwtStandard})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var standardClaims casdoorsdk.StandardClaims
+		if err = client.ParseJwtTokenInto(token, &standardClaims); err != nil {
+			t.Fatalf("Failed to verify %s standard token: %v", algorithm, err)
+		}
+		if standardClaims.Email != user.Email || standardClaims.Subject != user.Id {
+			t.Errorf("Unexpected %s standard claims %+v", algorithm, standardClaims)
+		}
+
+		token, err = issuer.MintToken(user, TokenOptions{ExpiresIn: -time.Minute})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = client.ParseJwtToken(token); err == nil {
+			t.Errorf("Expected expired %s token to be rejected", algorithm)
+		}
+
+		jwks, err := issuer.Jwks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var document struct {
+			Keys []map[string]interface{} `json:"keys"`
+		}
+		if err = json.Unmarshal(jwks, &document); err != nil || len(document.Keys) != 1 || document.Keys[0]["alg"] != algorithm {
+			t.Errorf("Unexpected %s JWKS document %s", algorithm, jwks)
+		}
+	}
+}
+
+func TestTrustRetiredCert(t *testing.T) {
+	oldIssuer, err := NewTokenIssuer("RS256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newIssuer, err := NewTokenIssuer("ES256")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &casdoorsdk.User{Owner: "built-in", Name: "alice"}
+	oldToken, err := oldIssuer.MintToken(user, TokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newIssuer.NewClient("built-in", "app-built-in")
+	if _, err = client.ParseJwtToken(oldToken); err == nil {
+		t.Fatalf("Expected token of the old cert to be rejected before trusting it")
+	}
+
+	client.TrustRetiredCert(oldIssuer.Cert(), time.Now().Add(time.Hour))
+	if _, err = client.ParseJwtToken(oldToken); err != nil {
+		t.Errorf("Expected token of the retired cert to be accepted, but got %v", err)
+	}
+
+	client = newIssuer.NewClient("built-in", "app-built-in")
+	client.TrustRetiredCert(oldIssuer.Cert(), time.Now().Add(-time.Hour))
+	if _, err = client.ParseJwtToken(oldToken); err == nil {
+		t.Errorf("Expected token of the expired retired cert to be rejected")
+	}
+}
+
+func TestMultipleCerts(t *testing.T) {
+	firstIssuer, err := NewTokenIssuer("RS256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstIssuer.Cert().Name = "cert-first"
+	secondIssuer, err := NewTokenIssuer("ES256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondIssuer.Cert().Name = "cert-second"
+
+	token, err := secondIssuer.MintToken(&casdoorsdk.User{Owner: "built-in", Name: "alice"}, TokenOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundleClient := casdoorsdk.NewClientWithConf(&casdoorsdk.AuthConfig{
+		Certificate: firstIssuer.Certificate() + secondIssuer.Certificate(),
+	})
+	claims, err := bundleClient.ParseJwtToken(token)
+	if err != nil {
+		t.Fatalf("Failed to verify token with a certificate bundle: %v", err)
+	}
+	if claims.Metadata.CertIndex != 1 || claims.Metadata.KeyId != "cert-second" {
+		t.Errorf("Unexpected metadata %+v", claims.Metadata)
+	}
+
+	certsClient := casdoorsdk.NewClientWithConf(&casdoorsdk.AuthConfig{
+		Certs: []*casdoorsdk.Cert{firstIssuer.Cert(), secondIssuer.Cert()},
+	})
+	claims, err = certsClient.ParseJwtToken(token)
+	if err != nil {
+		t.Fatalf("Failed to verify token with certs: %v", err)
+	}
+	if claims.Metadata.CertName != "cert-second" {
+		t.Errorf("Unexpected metadata %+v", claims.Metadata)
+	}
+}
@@ -15,8 +15,22 @@
 package casdoorsdk
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"time"
 )
 
 // Cert has the same definition as https://github.com/casdoor/casdoor/blob/master/object/cert.go#L24
@@ -38,16 +52,36 @@ type Cert struct {
 	AuthorityRootPublicKey string `xorm:"mediumtext" json:"authorityRootPublicKey"`
 }
 
-func (c *Client) GetGlobalCerts() ([]*Cert, error) {
-	url := c.GetUrl("get-global-certs", nil)
+// CertSpec describes the key material to generate for a new cert.
+type CertSpec struct {
+	Name        string
+	DisplayName string
+	// Scope defaults to "JWT".
+	Scope string
+	// Algorithm is one of RS256, RS384, RS512, ES256, ES384, ES512 or EdDSA, RS256 by default.
+	Algorithm string
+	// BitSize is the RSA key size, 4096 by default. It is ignored for other algorithms.
+	BitSize int
+	// ExpireInYears defaults to 20, like certs created in the Casdoor UI.
+	ExpireInYears int
+}
 
-	bytes, err := c.DoGetBytes(url)
-	if err != nil {
-		return nil, err
-	}
+// CertExpiry reports a cert expiring within the checked duration.
+type CertExpiry struct {
+	Cert     *Cert
+	IsGlobal bool
+	NotAfter time.Time
+	// Err is set when the certificate cannot be parsed, such a cert is always reported.
+	Err error
+}
 
+func (c *Client) GetGlobalCerts() ([]*Cert, error) {
+	return c.getGlobalCerts(context.Background())
+}
+
+func (c *Client) getGlobalCerts(ctx context.Context) ([]*Cert, error) {
 	var certs []*Cert
-	err = json.Unmarshal(bytes, &certs)
+	err := c.doGetWithContext(ctx, "get-global-certs", nil, &certs)
 	if err != nil {
 		return nil, err
 	}
@@ -55,19 +89,16 @@ func (c *Client) GetGlobalCerts() ([]*Cert, error) {
 }
 
 func (c *Client) GetCerts() ([]*Cert, error) {
+	return c.getCerts(context.Background())
+}
+
+func (c *Client) getCerts(ctx context.Context) ([]*Cert, error) {
 	queryMap := map[string]string{
 		"owner": c.OrganizationName,
 	}
 
-	url := c.GetUrl("get-certs", queryMap)
-
-	bytes, err := c.DoGetBytes(url)
-	if err != nil {
-		return nil, err
-	}
-
 	var certs []*Cert
-	err = json.Unmarshal(bytes, &certs)
+	err := c.doGetWithContext(ctx, "get-certs", queryMap, &certs)
 	if err != nil {
 		return nil, err
 	}
@@ -108,3 +139,229 @@ func (c *Client) DeleteCert(cert *Cert) (bool, error) {
 	_, affected, err := c.modifyCert("delete-cert", cert, nil)
 	return affected, err
 }
+
+// GenerateCert creates the key material described by spec locally and uploads it as a new cert.
+func (c *Client) GenerateCert(ctx context.Context, spec CertSpec) (*Cert, error) {
+	cert, err := NewCert(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Owner = c.OrganizationName
+	_, affected, err := c.modifyCertWithContext(ctx, "add-cert", cert, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !affected {
+		return nil, fmt.Errorf("cert %s was not added", cert.Name)
+	}
+	return cert, nil
+}
+
+// CheckCertExpiry lists the organization and global certs that expire within the given duration,
+// including the ones already expired, sorted by expiration time.
+func (c *Client) CheckCertExpiry(ctx context.Context, within time.Duration) ([]*CertExpiry, error) {
+	certs, err := c.getCerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	globalCerts, err := c.getGlobalCerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(within)
+	seen := map[string]bool{}
+	res := []*CertExpiry{}
+	for i, cert := range append(certs, globalCerts...) {
+		id := fmt.Sprintf("%s/%s", cert.Owner, cert.Name)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		expiry := &CertExpiry{Cert: cert, IsGlobal: i >= len(certs)}
+		x509Cert, err := cert.ParseX509()
+		if err != nil {
+			expiry.Err = err
+			res = append(res, expiry)
+			continue
+		}
+
+		expiry.NotAfter = x509Cert.NotAfter
+		if x509Cert.NotAfter.Before(deadline) {
+			res = append(res, expiry)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].NotAfter.Before(res[j].NotAfter)
+	})
+	return res, nil
+}
+
+// NewCert generates a private key and a self-signed x509 certificate as described by spec,
+// without uploading them to Casdoor.
+func NewCert(spec CertSpec) (*Cert, error) {
+	if spec.Name == "" {
+		return nil, errors.New("cert name is required")
+	}
+	if spec.Scope == "" {
+		spec.Scope = "JWT"
+	}
+	if spec.Algorithm == "" {
+		spec.Algorithm = "RS256"
+	}
+	if spec.ExpireInYears == 0 {
+		spec.ExpireInYears = 20
+	}
+
+	var privateKey crypto.Signer
+	var err error
+	switch spec.Algorithm {
+	case "RS256", "RS384", "RS512":
+		if spec.BitSize == 0 {
+			spec.BitSize = 4096
+		}
+		privateKey, err = rsa.GenerateKey(rand.Reader, spec.BitSize)
+	case "ES256":
+		spec.BitSize = 256
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ES384":
+		spec.BitSize = 384
+		privateKey, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "ES512":
+		spec.BitSize = 521
+		privateKey, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case "EdDSA":
+		spec.BitSize = 256
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported crypto algorithm: %s", spec.Algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Casdoor Organization"},
+			CommonName:   "Casdoor Cert",
+		},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(spec.ExpireInYears, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var privateKeyBlock *pem.Block
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		privateKeyBlock = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	case *ecdsa.PrivateKey:
+		keyBytes, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		privateKeyBlock = &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}
+	default:
+		keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		privateKeyBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
+	}
+
+	return &Cert{
+		Name:            spec.Name,
+		CreatedTime:     now.Format(time.RFC3339),
+		DisplayName:     spec.DisplayName,
+		Scope:           spec.Scope,
+		Type:            "x509",
+		CryptoAlgorithm: spec.Algorithm,
+		BitSize:         spec.BitSize,
+		ExpireInYears:   spec.ExpireInYears,
+		Certificate:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})),
+		PrivateKey:      string(pem.EncodeToMemory(privateKeyBlock)),
+	}, nil
+}
+
+// ParseX509 parses the PEM encoded certificate of the cert.
+func (cert *Cert) ParseX509() (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(cert.Certificate))
+	if block == nil {
+		return nil, fmt.Errorf("cert %s has no PEM encoded certificate", cert.Name)
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// PublicKey returns the public key of the cert, an *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+func (cert *Cert) PublicKey() (crypto.PublicKey, error) {
+	return parsePublicKeyFromPem([]byte(cert.Certificate))
+}
+
+// parsePublicKeyFromPem accepts both an x509 certificate and a PKIX public key.
+func parsePublicKeyFromPem(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("key must be PEM encoded")
+	}
+
+	return parsePublicKeyFromPemBlock(block)
+}
+
+// parsePublicKeysFromPem parses every block of a PEM bundle.
+func parsePublicKeysFromPem(data []byte) ([]crypto.PublicKey, error) {
+	publicKeys := []crypto.PublicKey{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		publicKey, err := parsePublicKeyFromPemBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	if len(publicKeys) == 0 {
+		return nil, errors.New("key must be PEM encoded")
+	}
+	return publicKeys, nil
+}
+
+func parsePublicKeyFromPemBlock(block *pem.Block) (crypto.PublicKey, error) {
+	if block.Type == "CERTIFICATE" {
+		x509Cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return x509Cert.PublicKey, nil
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		rsaPublicKey, rsaErr := x509.ParsePKCS1PublicKey(block.Bytes)
+		if rsaErr != nil {
+			return nil, err
+		}
+		return rsaPublicKey, nil
+	}
+	return publicKey, nil
+}
@@ -14,6 +14,11 @@
 
 package casdoorsdk
 
+import (
+	"context"
+	"time"
+)
+
 func GetGlobalCerts() ([]*Cert, error) {
 	return globalClient.GetGlobalCerts()
 }
@@ -33,3 +38,15 @@ func AddCert(cert *Cert) (bool, error) {
 func DeleteCert(cert *Cert) (bool, error) {
 	return globalClient.DeleteCert(cert)
 }
+
+func GenerateCert(ctx context.Context, spec CertSpec) (*Cert, error) {
+	return globalClient.GenerateCert(ctx, spec)
+}
+
+func CheckCertExpiry(ctx context.Context, within time.Duration) ([]*CertExpiry, error) {
+	return globalClient.CheckCertExpiry(ctx, within)
+}
+
+func RotateApplicationCert(ctx context.Context, applicationName string, spec CertSpec, options CertRotationOptions) (*CertRotation, error) {
+	return globalClient.RotateApplicationCert(ctx, applicationName, spec, options)
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type CertRotationOptions struct {
+	// GracePeriod is how long tokens signed by the old cert stay valid,
+	// it defaults to the application's ExpireInHours so that no issued token is cut short.
+	// The rotation is rejected when both are zero, as the old cert would be dropped at once.
+	GracePeriod time.Duration
+	// DryRun only reports the steps, without changing anything in Casdoor or in the client.
+	DryRun bool
+}
+
+type CertRotationStep struct {
+	Action      string
+	Description string
+	Done        bool
+}
+
+// CertRotation is the state of an application cert rotation started by RotateApplicationCert.
+// Call Complete once RetireAfter has passed to delete the old cert.
+type CertRotation struct {
+	Application string
+	OldCert     *Cert
+	NewCert     *Cert
+	RetireAfter time.Time
+	DryRun      bool
+	Steps       []*CertRotationStep
+
+	client *Client
+}
+
+func (r *CertRotation) addStep(action string, description string) *CertRotationStep {
+	step := &CertRotationStep{Action: action, Description: description}
+	r.Steps = append(r.Steps, step)
+	return step
+}
+
+// RotateApplicationCert creates a new cert from spec and switches the application to it.
+// Once the application is updated, the client verifies tokens with the new cert, and keeps
+// verifying tokens signed by the old cert until the grace period ends, after which
+// CertRotation.Complete deletes the old cert. The client is left unchanged when a step fails.
+func (c *Client) RotateApplicationCert(ctx context.Context, applicationName string, spec CertSpec, options CertRotationOptions) (*CertRotation, error) {
+	if options.GracePeriod < 0 {
+		return nil, fmt.Errorf("the grace period must not be negative, got %s", options.GracePeriod)
+	}
+
+	var application *Application
+	err := c.doGetWithContext(ctx, "get-application", map[string]string{"id": fmt.Sprintf("%s/%s", "admin", applicationName)}, &application)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, fmt.Errorf("application %s does not exist", applicationName)
+	}
+
+	oldCert, err := c.findCert(ctx, application.Cert)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Name == "" {
+		spec.Name = fmt.Sprintf("cert-%s-%s", applicationName, time.Now().Format("20060102150405"))
+	}
+	if spec.Algorithm == "" {
+		spec.Algorithm = oldCert.CryptoAlgorithm
+	}
+	// the bit size of the old cert only makes sense for the same algorithm,
+	// otherwise NewCert picks the default of the new one
+	if spec.BitSize == 0 && spec.Algorithm == oldCert.CryptoAlgorithm {
+		spec.BitSize = oldCert.BitSize
+	}
+	gracePeriod := options.GracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = time.Duration(application.ExpireInHours) * time.Hour
+	}
+	if gracePeriod == 0 {
+		return nil, fmt.Errorf("application %s has no token lifetime, set the grace period of the rotation", applicationName)
+	}
+
+	rotation := &CertRotation{
+		Application: applicationName,
+		OldCert:     oldCert,
+		RetireAfter: time.Now().Add(gracePeriod),
+		DryRun:      options.DryRun,
+		client:      c,
+	}
+
+	step := rotation.addStep("add-cert", fmt.Sprintf("generate %s cert %s and add it alongside %s", spec.Algorithm, spec.Name, oldCert.Name))
+	if !options.DryRun {
+		rotation.NewCert, err = c.GenerateCert(ctx, spec)
+		if err != nil {
+			return rotation, err
+		}
+		step.Done = true
+	}
+
+	step = rotation.addStep("update-application", fmt.Sprintf("switch application %s from cert %s to %s", applicationName, oldCert.Name, spec.Name))
+	if !options.DryRun {
+		application.Cert = rotation.NewCert.Name
+		_, affected, err := c.modifyApplicationWithContext(ctx, "update-application", application, nil)
+		if err != nil {
+			return rotation, err
+		}
+		if !affected {
+			return rotation, fmt.Errorf("application %s was not updated", applicationName)
+		}
+		step.Done = true
+	}
+
+	step = rotation.addStep("trust-cert", fmt.Sprintf("verify tokens with %s, and with %s until %s", spec.Name, oldCert.Name, rotation.RetireAfter.Format(time.RFC3339)))
+	if !options.DryRun {
+		c.TrustRetiredCert(oldCert, rotation.RetireAfter)
+		c.SetCertificate(rotation.NewCert.Certificate)
+		step.Done = true
+	}
+
+	rotation.addStep("delete-cert", fmt.Sprintf("delete cert %s after %s", oldCert.Name, rotation.RetireAfter.Format(time.RFC3339)))
+	return rotation, nil
+}
+
+// Complete deletes the old cert once the grace period is over,
+// unless another application still signs its tokens with it.
+func (r *CertRotation) Complete(ctx context.Context) error {
+	if r.DryRun {
+		return nil
+	}
+	if time.Now().Before(r.RetireAfter) {
+		return fmt.Errorf("cert %s is trusted until %s", r.OldCert.Name, r.RetireAfter.Format(time.RFC3339))
+	}
+
+	step := r.Steps[len(r.Steps)-1]
+	if step.Done {
+		return nil
+	}
+
+	var applications []*Application
+	err := r.client.doGetWithContext(ctx, "get-applications", map[string]string{"owner": "admin"}, &applications)
+	if err != nil {
+		return err
+	}
+	for _, application := range applications {
+		if application.Cert == r.OldCert.Name {
+			step.Description = fmt.Sprintf("keep cert %s, it is still used by application %s", r.OldCert.Name, application.Name)
+			step.Done = true
+			return nil
+		}
+	}
+
+	_, affected, err := r.client.modifyCertWithContext(ctx, "delete-cert", r.OldCert, nil)
+	if err != nil {
+		return err
+	}
+	if !affected {
+		return fmt.Errorf("cert %s was not deleted", r.OldCert.Name)
+	}
+	step.Done = true
+	return nil
+}
+
+// findCert looks a cert up by name among the organization certs and then the global ones.
+func (c *Client) findCert(ctx context.Context, name string) (*Cert, error) {
+	certs, err := c.getCerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	globalCerts, err := c.getGlobalCerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cert := range append(certs, globalCerts...) {
+		if cert.Name == name {
+			return cert, nil
+		}
+	}
+	return nil, fmt.Errorf("cert %s does not exist", name)
+}
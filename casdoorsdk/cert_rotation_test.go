@@ -0,0 +1,241 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeCertServer is a Casdoor server holding applications and certs for the rotation tests.
+type fakeCertServer struct {
+	applications []*Application
+	certs        []*Cert
+	posts        []string
+	// failing and unaffected are the actions answered with an error and with "Not affected"
+	failing    string
+	unaffected string
+}
+
+func (s *fakeCertServer) handle(r *http.Request) (interface{}, error) {
+	action := r.URL.Path[len("/api/"):]
+	if r.Method == "POST" {
+		s.posts = append(s.posts, action)
+	}
+	if action == s.failing {
+		return nil, fmt.Errorf("%s failed", action)
+	}
+	if action == s.unaffected {
+		return "Not affected", nil
+	}
+
+	switch action {
+	case "get-application":
+		for _, application := range s.applications {
+			if "admin/"+application.Name == r.URL.Query().Get("id") {
+				return application, nil
+			}
+		}
+		return nil, nil
+	case "get-applications":
+		return s.applications, nil
+	case "get-certs":
+		return s.certs, nil
+	case "get-global-certs":
+		return []*Cert{}, nil
+	case "add-cert":
+		var cert *Cert
+		err := json.NewDecoder(r.Body).Decode(&cert)
+		if err != nil {
+			return nil, err
+		}
+		s.certs = append(s.certs, cert)
+		return "Affected", nil
+	case "update-application":
+		var application *Application
+		err := json.NewDecoder(r.Body).Decode(&application)
+		if err != nil {
+			return nil, err
+		}
+		for i := range s.applications {
+			if s.applications[i].Name == application.Name {
+				s.applications[i] = application
+			}
+		}
+		return "Affected", nil
+	case "delete-cert":
+		return "Affected", nil
+	}
+	return nil, fmt.Errorf("unexpected request %s", action)
+}
+
+func newFakeCertServer(t *testing.T, algorithm string) (*fakeCertServer, *Client) {
+	oldCert, err := NewCert(CertSpec{Name: "cert-old", Algorithm: algorithm})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldCert.Owner = "built-in"
+
+	server := &fakeCertServer{
+		applications: []*Application{{Owner: "admin", Name: "app-built-in", Cert: "cert-old", ExpireInHours: 168}},
+		certs:        []*Cert{oldCert},
+	}
+	client := newFakeClient(t, server.handle)
+	client.SetCertificate(oldCert.Certificate)
+	return server, client
+}
+
+func TestRotateApplicationCert(t *testing.T) {
+	server, client := newFakeCertServer(t, "ES256")
+
+	rotation, err := client.RotateApplicationCert(context.Background(), "app-built-in", CertSpec{Name: "cert-new", Algorithm: "RS256"}, CertRotationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rotation.NewCert.CryptoAlgorithm != "RS256" || rotation.NewCert.BitSize != 4096 {
+		t.Errorf("expected a 4096 bits RS256 cert instead of the bit size of the ES256 cert, but got %s %d", rotation.NewCert.CryptoAlgorithm, rotation.NewCert.BitSize)
+	}
+	if server.applications[0].Cert != "cert-new" {
+		t.Errorf("expected the application to use cert-new, but got %s", server.applications[0].Cert)
+	}
+	if client.GetCertificate() != rotation.NewCert.Certificate {
+		t.Error("expected the client to verify tokens with the new cert")
+	}
+	certs, retiredIndex := client.getTrustedCerts()
+	if len(certs) != 2 || retiredIndex != 1 || certs[1].Name != "cert-old" {
+		t.Errorf("expected the old cert to be trusted during the grace period, but got %d certs", len(certs))
+	}
+	if time.Until(rotation.RetireAfter) < 167*time.Hour {
+		t.Errorf("expected the grace period to default to the token lifetime, but got %s", rotation.RetireAfter)
+	}
+	for _, step := range rotation.Steps[:3] {
+		if !step.Done {
+			t.Errorf("expected step %s to be done", step.Action)
+		}
+	}
+}
+
+func TestRotateApplicationCertFailure(t *testing.T) {
+	for _, action := range []string{"add-cert", "update-application"} {
+		for _, unaffected := range []bool{false, true} {
+			server, client := newFakeCertServer(t, "ES256")
+			oldCertificate := client.GetCertificate()
+			if unaffected {
+				server.unaffected = action
+			} else {
+				server.failing = action
+			}
+
+			_, err := client.RotateApplicationCert(context.Background(), "app-built-in", CertSpec{Name: "cert-new"}, CertRotationOptions{})
+			if err == nil {
+				t.Errorf("expected an error when %s fails", action)
+			}
+			if certs, retiredIndex := client.getTrustedCerts(); client.GetCertificate() != oldCertificate || len(certs) != retiredIndex {
+				t.Errorf("expected the client to keep the old cert when %s fails", action)
+			}
+		}
+	}
+}
+
+func TestRotateApplicationCertGracePeriod(t *testing.T) {
+	server, client := newFakeCertServer(t, "ES256")
+	server.applications[0].ExpireInHours = 0
+
+	_, err := client.RotateApplicationCert(context.Background(), "app-built-in", CertSpec{}, CertRotationOptions{})
+	if err == nil || len(server.posts) != 0 {
+		t.Errorf("expected a rotation without grace period to be rejected, got %v", err)
+	}
+	_, err = client.RotateApplicationCert(context.Background(), "app-built-in", CertSpec{}, CertRotationOptions{GracePeriod: -time.Hour})
+	if err == nil || len(server.posts) != 0 {
+		t.Errorf("expected a negative grace period to be rejected, got %v", err)
+	}
+}
+
+func TestRotateApplicationCertDryRun(t *testing.T) {
+	server, client := newFakeCertServer(t, "ES256")
+
+	rotation, err := client.RotateApplicationCert(context.Background(), "app-built-in", CertSpec{}, CertRotationOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(server.posts) != 0 || rotation.NewCert != nil {
+		t.Errorf("expected a dry run not to change anything, but got requests %v", server.posts)
+	}
+	if len(rotation.Steps) != 4 {
+		t.Fatalf("expected 4 steps, but got %d", len(rotation.Steps))
+	}
+	for _, step := range rotation.Steps {
+		if step.Done {
+			t.Errorf("expected step %s not to be done", step.Action)
+		}
+	}
+
+	err = rotation.Complete(context.Background())
+	if err != nil || len(server.posts) != 0 {
+		t.Errorf("expected completing a dry run to do nothing, but got error %v and requests %v", err, server.posts)
+	}
+}
+
+func TestCertRotationComplete(t *testing.T) {
+	server, client := newFakeCertServer(t, "ES256")
+
+	rotation, err := client.RotateApplicationCert(context.Background(), "app-built-in", CertSpec{Name: "cert-new"}, CertRotationOptions{GracePeriod: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotation.NewCert.CryptoAlgorithm != "ES256" {
+		t.Errorf("expected the algorithm of the old cert, but got %s", rotation.NewCert.CryptoAlgorithm)
+	}
+
+	err = rotation.Complete(context.Background())
+	if err == nil {
+		t.Fatal("expected an error before the grace period is over")
+	}
+
+	rotation.RetireAfter = time.Now().Add(-time.Second)
+	server.applications = append(server.applications, &Application{Owner: "admin", Name: "app-other", Cert: "cert-old"})
+	server.posts = nil
+	err = rotation.Complete(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.posts) != 0 {
+		t.Errorf("expected the cert still used by app-other to be kept, but got requests %v", server.posts)
+	}
+
+	rotation.Steps[3].Done = false
+	server.applications = server.applications[:1]
+	server.unaffected = "delete-cert"
+	err = rotation.Complete(context.Background())
+	if err == nil || rotation.Steps[3].Done {
+		t.Errorf("expected an error when the old cert is not deleted, got %v", err)
+	}
+
+	server.unaffected = ""
+	server.posts = nil
+	err = rotation.Complete(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(server.posts) != 1 || server.posts[0] != "delete-cert" || !rotation.Steps[3].Done {
+		t.Errorf("expected the old cert to be deleted, but got requests %v", server.posts)
+	}
+}
@@ -1,9 +1,16 @@
 package casdoorsdk
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	_ "embed"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 )
 
 // crypto/rsa: too few primes of given length to generate an RSA key
@@ -193,3 +200,70 @@ func TestDeleteCert(t *testing.T) {
 		}
 	}
 }
+
+func TestNewCert(t *testing.T) {
+	testCases := []struct {
+		algorithm string
+		checkKey  func(key interface{}) bool
+	}{
+		{algorithm: "RS256", checkKey: func(key interface{}) bool { _, ok := key.(*rsa.PublicKey); return ok }},
+		{algorithm: "ES384", checkKey: func(key interface{}) bool { _, ok := key.(*ecdsa.PublicKey); return ok }},
+		{algorithm: "EdDSA", checkKey: func(key interface{}) bool { _, ok := key.(ed25519.PublicKey); return ok }},
+	}
+
+	for _, tc := range testCases {
+		cert, err := NewCert(CertSpec{Name: "cert-" + tc.algorithm, Algorithm: tc.algorithm, BitSize: 2048, ExpireInYears: 2})
+		if err != nil {
+			t.Fatalf("For algorithm %s, cert generation failed: %v", tc.algorithm, err)
+		}
+
+		x509Cert, err := cert.ParseX509()
+		if err != nil {
+			t.Fatalf("For algorithm %s, certificate parsing failed: %v", tc.algorithm, err)
+		}
+		if x509Cert.NotAfter.Before(time.Now().AddDate(1, 11, 0)) {
+			t.Errorf("For algorithm %s, expected expiration in 2 years, but got %v", tc.algorithm, x509Cert.NotAfter)
+		}
+
+		publicKey, err := cert.PublicKey()
+		if err != nil || !tc.checkKey(publicKey) {
+			t.Errorf("For algorithm %s, unexpected public key %T, error %v", tc.algorithm, publicKey, err)
+		}
+	}
+}
+
+func TestCheckCertExpiry(t *testing.T) {
+	expiring, err := NewCert(CertSpec{Name: "cert-expiring", Algorithm: "ES256", ExpireInYears: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lasting, err := NewCert(CertSpec{Name: "cert-lasting", Algorithm: "ES256", ExpireInYears: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		switch r.URL.Path {
+		case "/api/get-certs":
+			return []*Cert{expiring, {Name: "cert-broken", Certificate: "not a certificate"}}, nil
+		case "/api/get-global-certs":
+			return []*Cert{lasting}, nil
+		}
+		return nil, fmt.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	expiries, err := client.CheckCertExpiry(context.Background(), 2*365*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiries) != 2 || expiries[0].Cert.Name != "cert-broken" || expiries[0].Err == nil || expiries[1].Cert.Name != "cert-expiring" {
+		t.Fatalf("expected the broken and the expiring certs, but got %v", expiries)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.CheckCertExpiry(ctx, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled context to stop the request, but got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDecisionCacheSize = 10000
+	defaultDecisionCacheTTL  = time.Minute
+)
+
+// DecisionCacheOptions configures the cache in front of Enforce and BatchEnforce.
+type DecisionCacheOptions struct {
+	// Size is the maximum number of cached decisions, the least recently used ones are evicted first.
+	// It defaults to 10000.
+	Size int
+	// TTL is how long an allowed decision is cached, it defaults to a minute.
+	TTL time.Duration
+	// NegativeTTL is how long a denied decision is cached, it defaults to TTL.
+	// A negative value disables the caching of denied decisions.
+	NegativeTTL time.Duration
+}
+
+type DecisionCacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Size      int
+}
+
+// decisionCache is an LRU cache of the enforcement results of single requests,
+// one result per permission matched by the request.
+type decisionCache struct {
+	options DecisionCacheOptions
+
+	mutex   sync.Mutex
+	entries map[decisionKey]*list.Element
+	lru     *list.List
+	stats   DecisionCacheStats
+}
+
+type decisionKey struct {
+	permissionId string
+	modelId      string
+	resourceId   string
+	request      string
+}
+
+type decisionEntry struct {
+	key       decisionKey
+	results   []bool
+	expiresAt time.Time
+}
+
+// EnableDecisionCache caches the decisions of Enforce and BatchEnforce, replacing the previous cache if any.
+// Policy changes in Casdoor are only seen once the decisions expire, or after InvalidateDecisions.
+func (c *Client) EnableDecisionCache(options DecisionCacheOptions) {
+	if options.Size <= 0 {
+		options.Size = defaultDecisionCacheSize
+	}
+	if options.TTL <= 0 {
+		options.TTL = defaultDecisionCacheTTL
+	}
+	if options.NegativeTTL == 0 {
+		options.NegativeTTL = options.TTL
+	}
+
+	c.decisionCache.Store(&decisionCache{
+		options: options,
+		entries: map[decisionKey]*list.Element{},
+		lru:     list.New(),
+	})
+}
+
+// DisableDecisionCache drops the cache, the following requests are always enforced by Casdoor.
+func (c *Client) DisableDecisionCache() {
+	c.decisionCache.Store((*decisionCache)(nil))
+}
+
+// InvalidateDecisions drops the cached decisions of a permission, and those enforced by model or resource
+// since they may depend on it. An empty permissionId drops every cached decision.
+func (c *Client) InvalidateDecisions(permissionId string) {
+	cache := c.getDecisionCache()
+	if cache == nil {
+		return
+	}
+
+	cache.invalidate(permissionId)
+}
+
+// GetDecisionCacheStats returns the hit, miss and eviction counts since the cache was enabled.
+func (c *Client) GetDecisionCacheStats() DecisionCacheStats {
+	cache := c.getDecisionCache()
+	if cache == nil {
+		return DecisionCacheStats{}
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	stats := cache.stats
+	stats.Size = cache.lru.Len()
+	return stats
+}
+
+func (c *Client) getDecisionCache() *decisionCache {
+	cache, _ := c.decisionCache.Load().(*decisionCache)
+	return cache
+}
+
+func (cache *decisionCache) get(key decisionKey) ([]bool, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		cache.stats.Misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*decisionEntry)
+	if time.Now().After(entry.expiresAt) {
+		cache.remove(element)
+		cache.stats.Misses++
+		return nil, false
+	}
+
+	cache.lru.MoveToFront(element)
+	cache.stats.Hits++
+	return entry.results, true
+}
+
+func (cache *decisionCache) set(key decisionKey, results []bool) {
+	ttl := cache.options.TTL
+	if !anyAllowed(results) {
+		ttl = cache.options.NegativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry := &decisionEntry{key: key, results: results, expiresAt: time.Now().Add(ttl)}
+	if element, ok := cache.entries[key]; ok {
+		element.Value = entry
+		cache.lru.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.lru.PushFront(entry)
+	for cache.lru.Len() > cache.options.Size {
+		cache.remove(cache.lru.Back())
+		cache.stats.Evictions++
+	}
+}
+
+func (cache *decisionCache) invalidate(permissionId string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for key, element := range cache.entries {
+		if permissionId == "" || key.permissionId == "" || key.permissionId == permissionId {
+			cache.remove(element)
+		}
+	}
+}
+
+func (cache *decisionCache) remove(element *list.Element) {
+	cache.lru.Remove(element)
+	delete(cache.entries, element.Value.(*decisionEntry).key)
+}
+
+func anyAllowed(results []bool) bool {
+	for _, isAllow := range results {
+		if isAllow {
+			return true
+		}
+	}
+	return false
+}
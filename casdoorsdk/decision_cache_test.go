@@ -0,0 +1,105 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDecisionCache(t *testing.T) {
+	// alice may read anything, every other request is denied
+	var calls int64
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		atomic.AddInt64(&calls, 1)
+
+		allow := func(request []interface{}) bool {
+			return request[0] == "alice" && request[2] == "read"
+		}
+		if r.URL.Path == "/api/batch-enforce" {
+			var requests [][]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&requests)
+			row := []bool{}
+			for _, request := range requests {
+				row = append(row, allow(request))
+			}
+			return [][]bool{row}, nil
+		}
+
+		var request []interface{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		return []bool{allow(request)}, nil
+	})
+	client.EnableDecisionCache(DecisionCacheOptions{Size: 2, TTL: time.Minute})
+
+	alice := CasbinRequest{"alice", "data1", "read"}
+	bob := CasbinRequest{"bob", "data1", "read"}
+	for i := 0; i < 3; i++ {
+		if allowed, err := client.Enforce("built-in/permission", "", "", alice); err != nil || !allowed {
+			t.Fatalf("Expected alice to be allowed, got %v, %v", allowed, err)
+		}
+		if allowed, err := client.Enforce("built-in/permission", "", "", bob); err != nil || allowed {
+			t.Fatalf("Expected bob to be denied, got %v, %v", allowed, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls to Casdoor, got %d", calls)
+	}
+
+	allows, err := client.BatchEnforce("built-in/permission", "", "", []CasbinRequest{alice, {"alice", "data1", "write"}, bob})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allows) != 1 || len(allows[0]) != 3 || !allows[0][0] || allows[0][1] || allows[0][2] {
+		t.Errorf("Unexpected batch results %v", allows)
+	}
+	if calls != 3 {
+		t.Errorf("Expected only the missing request to be sent, got %d calls", calls)
+	}
+
+	stats := client.GetDecisionCacheStats()
+	if stats.Hits != 6 || stats.Misses != 3 || stats.Evictions != 1 || stats.Size != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	client.InvalidateDecisions("built-in/permission")
+	if _, err = client.Enforce("built-in/permission", "", "", bob); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 4 || client.GetDecisionCacheStats().Size != 1 {
+		t.Errorf("Expected the invalidated decision to be enforced again, got %d calls", calls)
+	}
+}
+
+func TestDecisionCacheDefaultTTL(t *testing.T) {
+	var calls int64
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		atomic.AddInt64(&calls, 1)
+		return []bool{true}, nil
+	})
+	client.EnableDecisionCache(DecisionCacheOptions{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Enforce("built-in/permission", "", "", CasbinRequest{"alice", "data1", "read"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the decision to be cached for the default TTL, got %d calls", calls)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// EffectivePermission is a resource and action a user is granted, or denied, by a permission.
+type EffectivePermission struct {
+	Resource string
+	Action   string
+	Effect   string
+	// Domain is the domain the grant applies in, empty when the permission has no domains.
+	Domain       string
+	PermissionId string
+	// Via is the subject of the permission the user is granted it through:
+	// the user itself, one of its groups or one of its roles.
+	Via string
+}
+
+// GetUserEffectivePermissions expands the enabled permissions of the organization into the list of
+// resources and actions the user is granted, directly, through its groups and their parent groups,
+// or through its enabled roles including the inherited ones. Roles restricted to domains only grant
+// the permissions of those domains.
+func (c *Client) GetUserEffectivePermissions(ctx context.Context, user *User) ([]*EffectivePermission, error) {
+	queryMap := map[string]string{
+		"owner": c.OrganizationName,
+	}
+
+	var permissions []*Permission
+	err := c.doGetWithContext(ctx, "get-permissions", queryMap, &permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []*Role
+	err = c.doGetWithContext(ctx, "get-roles", queryMap, &roles)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []*Group
+	if len(user.Groups) != 0 {
+		err = c.doGetWithContext(ctx, "get-groups", queryMap, &groups)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return getEffectivePermissions(user, permissions, roles, groups), nil
+}
+
+func getEffectivePermissions(user *User, permissions []*Permission, roles []*Role, groups []*Group) []*EffectivePermission {
+	userId := getFullId(user.Owner, user.Name)
+	groupIds := getUserGroupIds(user, groups)
+	roleDomains := getUserRoleDomains(userId, groupIds, roles)
+
+	res := []*EffectivePermission{}
+	seen := map[EffectivePermission]bool{}
+	add := func(permission *Permission, via string, domains []string) {
+		permissionId := getFullId(permission.Owner, permission.Name)
+		effect := permission.Effect
+		if effect == "" {
+			effect = "Allow"
+		}
+
+		permissionDomains := permission.Domains
+		if len(permissionDomains) == 0 {
+			permissionDomains = []string{""}
+		}
+
+		for _, domain := range permissionDomains {
+			if domain != "" && domains != nil && !containsString(domains, domain) {
+				continue
+			}
+
+			for _, resource := range permission.Resources {
+				for _, action := range permission.Actions {
+					effectivePermission := EffectivePermission{
+						Resource:     resource,
+						Action:       action,
+						Effect:       effect,
+						Domain:       domain,
+						PermissionId: permissionId,
+					}
+					if seen[effectivePermission] {
+						continue
+					}
+					seen[effectivePermission] = true
+
+					effectivePermission.Via = via
+					res = append(res, &effectivePermission)
+				}
+			}
+		}
+	}
+
+	for _, permission := range permissions {
+		if !permission.IsEnabled {
+			continue
+		}
+
+		for _, subject := range permission.Users {
+			if subject == userId || subject == fmt.Sprintf("%s/*", user.Owner) {
+				add(permission, subject, nil)
+			}
+		}
+		for _, subject := range permission.Groups {
+			if containsString(groupIds, subject) {
+				add(permission, subject, nil)
+			}
+		}
+		for _, subject := range permission.Roles {
+			if domains, ok := roleDomains[subject]; ok {
+				add(permission, subject, domains)
+			}
+		}
+	}
+	return res
+}
+
+// getUserGroupIds returns the groups of the user and their parent groups.
+func getUserGroupIds(user *User, groups []*Group) []string {
+	groupMap := map[string]*Group{}
+	for _, group := range groups {
+		groupMap[getFullId(group.Owner, group.Name)] = group
+	}
+
+	var res []string
+	for _, groupId := range user.Groups {
+		if !strings.Contains(groupId, "/") {
+			groupId = getFullId(user.Owner, groupId)
+		}
+
+		for groupId != "" && !containsString(res, groupId) {
+			res = append(res, groupId)
+
+			group, ok := groupMap[groupId]
+			if !ok || group.IsTopGroup || group.ParentId == "" || group.ParentId == group.Owner {
+				break
+			}
+			groupId = getFullId(group.Owner, group.ParentId)
+		}
+	}
+	return res
+}
+
+// getUserRoleDomains returns the enabled roles of the user, directly or through its groups, and the roles
+// they inherit. Each role maps to the domains it is restricted to, nil for all domains.
+func getUserRoleDomains(userId string, groupIds []string, roles []*Role) map[string][]string {
+	graph := NewRoleGraph(roles)
+
+	res := map[string][]string{}
+	for _, roleId := range graph.Roles() {
+		role := graph.GetRole(roleId)
+		if !role.IsEnabled {
+			continue
+		}
+
+		isMember := containsString(role.Users, userId)
+		for _, groupId := range role.Groups {
+			isMember = isMember || containsString(groupIds, groupId)
+		}
+		if !isMember {
+			continue
+		}
+
+		for _, id := range append([]string{roleId}, graph.Ancestors(roleId, "")...) {
+			res[id] = graph.GetRole(id).Domains
+			if len(res[id]) == 0 {
+				res[id] = nil
+			}
+		}
+	}
+	return res
+}
@@ -15,8 +15,8 @@
 package casdoorsdk
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -63,17 +63,13 @@ func (c *Client) GetPaginationEnforcers(p int, pageSize int, queryMap map[string
 
 	url := c.GetUrl("get-enforcers", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var enforcers []*Enforcer
+	total, err := c.doGetPagination(url, &enforcers)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	enforcers, ok := response.Data.([]*Enforcer)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return enforcers, int(response.Data2.(float64)), nil
+	return enforcers, total, nil
 }
 
 func (c *Client) GetEnforcer(name string) (*Enforcer, error) {
@@ -110,3 +106,99 @@ func (c *Client) DeleteEnforcer(enforcer *Enforcer) (bool, error) {
 	_, affected, err := c.modifyEnforcer("delete-enforcer", enforcer, nil)
 	return affected, err
 }
+
+// ReloadEnforcer makes Casdoor initialize the enforcer again, reloading its model and the policies of its adapter,
+// e.g. after the policies have been changed in the database of the adapter. Casdoor has no reload endpoint,
+// so the stored enforcer is updated unchanged, which makes Casdoor initialize it again.
+func (c *Client) ReloadEnforcer(ctx context.Context, enforcer *Enforcer) (bool, error) {
+	var stored *Enforcer
+	err := c.doGetWithContext(ctx, "get-enforcer", map[string]string{"id": c.getEnforcerId(enforcer)}, &stored)
+	if err != nil {
+		return false, err
+	}
+	if stored == nil {
+		return false, fmt.Errorf("enforcer %s does not exist", c.getEnforcerId(enforcer))
+	}
+
+	_, affected, err := c.modifyEnforcerWithContext(ctx, "update-enforcer", stored, nil)
+	return affected, err
+}
+
+// casbinRule is the wire format of an enforcer policy rule, whose id is numeric unlike PermissionRule.Id.
+type casbinRule struct {
+	Ptype string `json:"ptype"`
+	V0    string `json:"v0"`
+	V1    string `json:"v1"`
+	V2    string `json:"v2"`
+	V3    string `json:"v3"`
+	V4    string `json:"v4"`
+	V5    string `json:"v5"`
+}
+
+func newCasbinRule(rule *PermissionRule) *casbinRule {
+	return &casbinRule{Ptype: rule.Ptype, V0: rule.V0, V1: rule.V1, V2: rule.V2, V3: rule.V3, V4: rule.V4, V5: rule.V5}
+}
+
+// GetPolicies returns the policy rules loaded by the enforcer, both p and g rules.
+func (c *Client) GetPolicies(ctx context.Context, enforcer *Enforcer) ([]*PermissionRule, error) {
+	return c.getPolicies(ctx, c.getEnforcerId(enforcer))
+}
+
+func (c *Client) getPolicies(ctx context.Context, id string) ([]*PermissionRule, error) {
+	queryMap := map[string]string{
+		"id": id,
+	}
+
+	var rules []*casbinRule
+	err := c.doGetWithContext(ctx, "get-policies", queryMap, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := []*PermissionRule{}
+	for _, rule := range rules {
+		policies = append(policies, &PermissionRule{Ptype: rule.Ptype, V0: rule.V0, V1: rule.V1, V2: rule.V2, V3: rule.V3, V4: rule.V4, V5: rule.V5})
+	}
+	return policies, nil
+}
+
+func (c *Client) AddPolicy(ctx context.Context, enforcer *Enforcer, policy *PermissionRule) (bool, error) {
+	return c.modifyPolicy(ctx, "add-policy", enforcer, newCasbinRule(policy))
+}
+
+func (c *Client) UpdatePolicy(ctx context.Context, enforcer *Enforcer, oldPolicy *PermissionRule, newPolicy *PermissionRule) (bool, error) {
+	return c.modifyPolicy(ctx, "update-policy", enforcer, []*casbinRule{newCasbinRule(oldPolicy), newCasbinRule(newPolicy)})
+}
+
+func (c *Client) RemovePolicy(ctx context.Context, enforcer *Enforcer, policy *PermissionRule) (bool, error) {
+	return c.modifyPolicy(ctx, "remove-policy", enforcer, newCasbinRule(policy))
+}
+
+// modifyPolicy is an encapsulation of enforcer policy CUD(Create, Update, Delete) operations.
+// possible actions are `add-policy`, `update-policy`, `remove-policy`,
+func (c *Client) modifyPolicy(ctx context.Context, action string, enforcer *Enforcer, body interface{}) (bool, error) {
+	queryMap := map[string]string{
+		"id": c.getEnforcerId(enforcer),
+	}
+
+	postBytes, err := json.Marshal(body)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.doPostWithContext(ctx, action, queryMap, postBytes, false, false)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Data == "Affected", nil
+}
+
+// getEnforcerId returns the id of the enforcer, in the client's organization when it has no owner.
+func (c *Client) getEnforcerId(enforcer *Enforcer) string {
+	owner := enforcer.Owner
+	if owner == "" {
+		owner = c.OrganizationName
+	}
+	return fmt.Sprintf("%s/%s", owner, enforcer.Name)
+}
@@ -15,6 +15,7 @@
 package casdoorsdk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 )
@@ -30,6 +31,15 @@ type PermissionRule struct {
 	Id    string `xorm:"varchar(100) index not null default ''" json:"id"`
 }
 
+// ToArray returns the rule as a Casbin policy line, starting with its ptype and without trailing empty values.
+func (rule *PermissionRule) ToArray() []string {
+	res := []string{rule.Ptype, rule.V0, rule.V1, rule.V2, rule.V3, rule.V4, rule.V5}
+	for len(res) > 1 && res[len(res)-1] == "" {
+		res = res[:len(res)-1]
+	}
+	return res
+}
+
 type CasbinRequest = []interface{}
 
 func (c *Client) Enforce(permissionId, modelId, resourceId string, casbinRequest CasbinRequest) (bool, error) {
@@ -38,41 +48,114 @@ func (c *Client) Enforce(permissionId, modelId, resourceId string, casbinRequest
 		return false, err
 	}
 
+	cache := c.getDecisionCache()
+	key := decisionKey{permissionId: permissionId, modelId: modelId, resourceId: resourceId, request: string(postBytes)}
+	if cache != nil {
+		if results, ok := cache.get(key); ok {
+			return anyAllowed(results), nil
+		}
+	}
+
 	res, err := c.doEnforce("enforce", permissionId, modelId, resourceId, postBytes)
 	if err != nil {
 		return false, err
 	}
 
-	results, ok := res.Data.([]interface{})
-	if !ok {
-		return false, errors.New("invalid data")
+	results, err := parseEnforceResults(res.Data)
+	if err != nil {
+		return false, err
 	}
 
-	for _, result := range results {
-		isAllow, ok := result.(bool)
-		if !ok {
-			return false, errors.New("invalid data")
+	if cache != nil {
+		cache.set(key, results)
+	}
+	return anyAllowed(results), nil
+}
+
+func Enforce(permissionId, modelId, resourceId string, casbinRequest CasbinRequest) (bool, error) {
+	return globalClient.Enforce(permissionId, modelId, resourceId, casbinRequest)
+}
+
+// BatchEnforce returns one row of results per permission matched by the requests, with one result per request.
+// When the decision cache is enabled, only the requests missing from the cache are sent to Casdoor.
+func (c *Client) BatchEnforce(permissionId, modelId, resourceId string, casbinRequests []CasbinRequest) ([][]bool, error) {
+	cache := c.getDecisionCache()
+	if cache == nil {
+		return c.batchEnforce(context.Background(), permissionId, modelId, resourceId, casbinRequests)
+	}
+
+	keys := make([]decisionKey, len(casbinRequests))
+	columns := make([][]bool, len(casbinRequests))
+	var missingIndexes []int
+	var missingRequests []CasbinRequest
+	for i, casbinRequest := range casbinRequests {
+		request, err := json.Marshal(casbinRequest)
+		if err != nil {
+			return nil, err
 		}
 
-		if isAllow {
-			return isAllow, nil
+		keys[i] = decisionKey{permissionId: permissionId, modelId: modelId, resourceId: resourceId, request: string(request)}
+		if results, ok := cache.get(keys[i]); ok {
+			columns[i] = results
+		} else {
+			missingIndexes = append(missingIndexes, i)
+			missingRequests = append(missingRequests, casbinRequest)
 		}
 	}
 
-	return false, nil
+	if len(missingRequests) != 0 {
+		allows, err := c.batchEnforce(context.Background(), permissionId, modelId, resourceId, missingRequests)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, i := range missingIndexes {
+			columns[i] = make([]bool, len(allows))
+			for k, row := range allows {
+				if j >= len(row) {
+					return nil, errors.New("invalid data")
+				}
+				columns[i][k] = row[j]
+			}
+			cache.set(keys[i], columns[i])
+		}
+	}
+
+	// the matched permissions changed since some decisions were cached, so the rows cannot be assembled
+	for _, column := range columns {
+		if len(column) != len(columns[0]) {
+			return c.batchEnforce(context.Background(), permissionId, modelId, resourceId, casbinRequests)
+		}
+	}
+
+	var allows [][]bool
+	for k := 0; len(columns) != 0 && k < len(columns[0]); k++ {
+		row := make([]bool, len(columns))
+		for i, column := range columns {
+			row[i] = column[k]
+		}
+		allows = append(allows, row)
+	}
+	return allows, nil
 }
 
-func Enforce(permissionId, modelId, resourceId string, casbinRequest CasbinRequest) (bool, error) {
-	return globalClient.Enforce(permissionId, modelId, resourceId, casbinRequest)
+func BatchEnforce(permissionId, modelId, resourceId string, casbinRequests []CasbinRequest) ([][]bool, error) {
+	return globalClient.BatchEnforce(permissionId, modelId, resourceId, casbinRequests)
 }
 
-func (c *Client) BatchEnforce(permissionId, modelId, resourceId string, casbinRequests []CasbinRequest) ([][]bool, error) {
+func (c *Client) batchEnforce(ctx context.Context, permissionId, modelId, resourceId string, casbinRequests []CasbinRequest) ([][]bool, error) {
 	postBytes, err := json.Marshal(casbinRequests)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := c.doEnforce("batch-enforce", permissionId, modelId, resourceId, postBytes)
+	queryMap := map[string]string{
+		"permissionId": permissionId,
+		"modelId":      modelId,
+		"resourceId":   resourceId,
+	}
+
+	res, err := c.doPostWithContext(ctx, "batch-enforce", queryMap, postBytes, false, false)
 	if err != nil {
 		return nil, err
 	}
@@ -84,17 +167,9 @@ func (c *Client) BatchEnforce(permissionId, modelId, resourceId string, casbinRe
 	}
 
 	for _, d := range data {
-		elems, ok := d.([]interface{})
-		if !ok {
-			return nil, errors.New("invalid data")
-		}
-		var permRes []bool
-		for _, el := range elems {
-			r, ok := el.(bool)
-			if !ok {
-				return nil, errors.New("invalid data")
-			}
-			permRes = append(permRes, r)
+		permRes, err := parseEnforceResults(d)
+		if err != nil {
+			return nil, err
 		}
 		allows = append(allows, permRes)
 	}
@@ -102,10 +177,6 @@ func (c *Client) BatchEnforce(permissionId, modelId, resourceId string, casbinRe
 	return allows, nil
 }
 
-func BatchEnforce(permissionId, modelId, resourceId string, casbinRequests []CasbinRequest) ([][]bool, error) {
-	return globalClient.BatchEnforce(permissionId, modelId, resourceId, casbinRequests)
-}
-
 func (c *Client) doEnforce(action string, permissionId, modelId, resourceId string, postBytes []byte) (*Response, error) {
 	queryMap := map[string]string{
 		"permissionId": permissionId,
@@ -121,3 +192,21 @@ func (c *Client) doEnforce(action string, permissionId, modelId, resourceId stri
 
 	return resp, nil
 }
+
+// parseEnforceResults parses the data of an enforce response, one result per matched permission.
+func parseEnforceResults(data interface{}) ([]bool, error) {
+	elems, ok := data.([]interface{})
+	if !ok {
+		return nil, errors.New("invalid data")
+	}
+
+	var results []bool
+	for _, el := range elems {
+		r, ok := el.(bool)
+		if !ok {
+			return nil, errors.New("invalid data")
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+)
+
+// permissionSnapshotTTL is how long EnforceEx reuses the model and policies a permission is explained with.
+const permissionSnapshotTTL = time.Minute
+
+// EnforceResult is a decision together with the policy rules that produced it.
+type EnforceResult struct {
+	Allowed bool
+	// Results are the results Enforce combines, one per permission evaluated by Casdoor.
+	// They are empty when the request has been enforced locally.
+	Results []bool
+	// PermissionIds are the ids of the permissions of Results, when Casdoor reports them.
+	PermissionIds []string
+	// AllowIndex is the index of the result that produced the allow, -1 when the request is denied.
+	AllowIndex int
+	// PermissionId is the permission that produced the decision, empty when no rule matched.
+	PermissionId string
+	// MatchedRules are the policy rules that produced the decision, as explained by Casbin:
+	// the allowing rules when the request is allowed, the denying ones when a deny rule matched.
+	// A denied request without matched rules is denied because no rule matched.
+	MatchedRules []*PermissionRule
+}
+
+// EnforceEx enforces the request remotely like Enforce, and explains the decision with the policy rules
+// of the permission that produced it, which are evaluated locally since Casdoor has no explaining endpoint.
+// The model and policies of a permission are downloaded once a minute at most, so the explanation may lag
+// behind a policy change for that long while the decision does not. The decision cache is not used.
+func (c *Client) EnforceEx(ctx context.Context, permissionId, modelId, resourceId string, casbinRequest CasbinRequest) (*EnforceResult, error) {
+	queryMap := map[string]string{
+		"permissionId": permissionId,
+		"modelId":      modelId,
+		"resourceId":   resourceId,
+	}
+
+	return c.enforceEx(ctx, queryMap, casbinRequest)
+}
+
+// EnforceEx evaluates and explains the request locally, or remotely when the snapshot is missing or stale.
+func (e *LocalEnforcer) EnforceEx(ctx context.Context, casbinRequest CasbinRequest) (*EnforceResult, error) {
+	enforcer, rules := e.getSnapshot()
+	if enforcer == nil {
+		return e.client.enforceEx(ctx, e.getQueryMap(), casbinRequest)
+	}
+
+	allowed, matchedRules, err := explainRequest(enforcer, rules, casbinRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnforceResult{Allowed: allowed, AllowIndex: -1, MatchedRules: matchedRules}
+	if allowed {
+		result.AllowIndex = 0
+	}
+	if len(matchedRules) != 0 {
+		result.PermissionId = matchedRules[0].V5
+	}
+	return result, nil
+}
+
+func (c *Client) enforceEx(ctx context.Context, queryMap map[string]string, casbinRequest CasbinRequest) (*EnforceResult, error) {
+	postBytes, err := json.Marshal(casbinRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doPostWithContext(ctx, "enforce", queryMap, postBytes, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := parseEnforceResults(res.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EnforceResult{Results: results, AllowIndex: -1}
+	for i, isAllow := range results {
+		if isAllow {
+			result.Allowed = true
+			result.AllowIndex = i
+			break
+		}
+	}
+
+	// data2 lists the evaluated permissions in the same order as the results
+	if ids, ok := res.Data2.([]interface{}); ok && len(ids) == len(results) {
+		for _, id := range ids {
+			s, _ := id.(string)
+			result.PermissionIds = append(result.PermissionIds, s)
+		}
+	}
+
+	var permissionIds []string
+	switch {
+	case result.Allowed && result.PermissionIds != nil:
+		permissionIds = []string{result.PermissionIds[result.AllowIndex]}
+	case result.PermissionIds != nil:
+		permissionIds = result.PermissionIds
+	case queryMap["permissionId"] != "":
+		permissionIds = []string{queryMap["permissionId"]}
+	}
+
+	for _, permissionId := range permissionIds {
+		if permissionId == "" {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		matchedRules, err := c.explainPermission(ctx, permissionId, casbinRequest)
+		if err != nil {
+			return nil, err
+		}
+		if len(matchedRules) != 0 {
+			result.PermissionId = permissionId
+			result.MatchedRules = append(result.MatchedRules, matchedRules...)
+		}
+	}
+	return result, nil
+}
+
+// cachedPermissionSnapshot is the local enforcer of a permission built by explainPermission.
+type cachedPermissionSnapshot struct {
+	enforcer    *casbin.Enforcer
+	rules       []*PermissionRule
+	fetchedTime time.Time
+}
+
+// explainPermission evaluates the request locally against the policy rules of a permission,
+// and returns the rules that produced the decision.
+func (c *Client) explainPermission(ctx context.Context, permissionId string, casbinRequest CasbinRequest) ([]*PermissionRule, error) {
+	snapshot, err := c.getCachedPermissionSnapshot(ctx, permissionId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, matchedRules, err := explainRequest(snapshot.enforcer, snapshot.rules, casbinRequest)
+	return matchedRules, err
+}
+
+// getCachedPermissionSnapshot returns the local enforcer of the permission, cached for permissionSnapshotTTL.
+func (c *Client) getCachedPermissionSnapshot(ctx context.Context, permissionId string) (*cachedPermissionSnapshot, error) {
+	cached, ok := c.permissionSnapshots.Load(permissionId)
+	if ok && time.Since(cached.(*cachedPermissionSnapshot).fetchedTime) < permissionSnapshotTTL {
+		return cached.(*cachedPermissionSnapshot), nil
+	}
+
+	modelText, rules, err := c.getPermissionSnapshot(ctx, permissionId)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcer, err := newCasbinEnforcer(modelText, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &cachedPermissionSnapshot{enforcer: enforcer, rules: rules, fetchedTime: time.Now()}
+	c.permissionSnapshots.Store(permissionId, snapshot)
+	return snapshot, nil
+}
+
+// explainRequest enforces the request and returns the rules Casbin explains the decision with.
+// The rules loaded in the enforcer have been cut to their policy definition, so the matched rules
+// are looked up among the original ones to keep the extra values such as the permission id.
+func explainRequest(enforcer *casbin.Enforcer, rules []*PermissionRule, casbinRequest CasbinRequest) (bool, []*PermissionRule, error) {
+	allowed, explain, err := enforcer.EnforceEx(casbinRequest...)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(explain) == 0 {
+		return allowed, nil, nil
+	}
+
+	var matchedRules []*PermissionRule
+	for _, rule := range rules {
+		if rule.Ptype != "p" {
+			continue
+		}
+
+		values := make([]string, 6)
+		copy(values, rule.ToArray()[1:])
+		matched := len(explain) <= len(values)
+		for i := 0; matched && i < len(explain); i++ {
+			matched = values[i] == explain[i]
+		}
+		if matched {
+			matchedRules = append(matchedRules, rule)
+		}
+	}
+
+	if len(matchedRules) == 0 {
+		rule := &PermissionRule{Ptype: "p"}
+		values := []*string{&rule.V0, &rule.V1, &rule.V2, &rule.V3, &rule.V4, &rule.V5}
+		for i := 0; i < len(explain) && i < len(values); i++ {
+			*values[i] = explain[i]
+		}
+		matchedRules = append(matchedRules, rule)
+	}
+	return allowed, matchedRules, nil
+}
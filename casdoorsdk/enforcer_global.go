@@ -14,6 +14,8 @@
 
 package casdoorsdk
 
+import "context"
+
 func GetEnforcers() ([]*Enforcer, error) {
 	return globalClient.GetEnforcers()
 }
@@ -37,3 +39,23 @@ func AddEnforcer(enforcer *Enforcer) (bool, error) {
 func DeleteEnforcer(enforcer *Enforcer) (bool, error) {
 	return globalClient.DeleteEnforcer(enforcer)
 }
+
+func ReloadEnforcer(ctx context.Context, enforcer *Enforcer) (bool, error) {
+	return globalClient.ReloadEnforcer(ctx, enforcer)
+}
+
+func GetPolicies(ctx context.Context, enforcer *Enforcer) ([]*PermissionRule, error) {
+	return globalClient.GetPolicies(ctx, enforcer)
+}
+
+func AddPolicy(ctx context.Context, enforcer *Enforcer, policy *PermissionRule) (bool, error) {
+	return globalClient.AddPolicy(ctx, enforcer, policy)
+}
+
+func UpdatePolicy(ctx context.Context, enforcer *Enforcer, oldPolicy *PermissionRule, newPolicy *PermissionRule) (bool, error) {
+	return globalClient.UpdatePolicy(ctx, enforcer, oldPolicy, newPolicy)
+}
+
+func RemovePolicy(ctx context.Context, enforcer *Enforcer, policy *PermissionRule) (bool, error) {
+	return globalClient.RemovePolicy(ctx, enforcer, policy)
+}
@@ -1,8 +1,15 @@
 package casdoorsdk
 
 import (
+	"context"
 	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -185,3 +192,84 @@ func TestDeleteEnforcer(t *testing.T) {
 		}
 	}
 }
+
+func TestEnforcerLifecycle(t *testing.T) {
+	var posts []string
+	var updated *Enforcer
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		if r.URL.Query().Get("id") != "built-in/enforcer-rbac" && r.URL.Path != "/api/test-adapter-connection" {
+			return nil, fmt.Errorf("unexpected id %s", r.URL.Query().Get("id"))
+		}
+
+		switch r.URL.Path {
+		case "/api/get-enforcer":
+			return &Enforcer{Owner: "built-in", Name: "enforcer-rbac", Model: "built-in/model-rbac", Adapter: "built-in/adapter-db"}, nil
+		case "/api/update-enforcer":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			return "Affected", nil
+		case "/api/get-policies":
+			return []map[string]interface{}{{"id": 1, "ptype": "p", "v0": "alice", "v1": "data1", "v2": "read"}}, nil
+		case "/api/add-policy", "/api/update-policy", "/api/remove-policy":
+			bytes, _ := io.ReadAll(r.Body)
+			posts = append(posts, fmt.Sprintf("%s %s", r.URL.Path, strings.TrimSpace(string(bytes))))
+			return "Affected", nil
+		case "/api/test-adapter-connection":
+			if r.URL.Query().Get("id") != "org-adapters/adapter-db" {
+				return nil, fmt.Errorf("unexpected adapter id %s", r.URL.Query().Get("id"))
+			}
+			var adapter Adapter
+			_ = json.NewDecoder(r.Body).Decode(&adapter)
+			if adapter.Table != "casbin_rule" {
+				return nil, errors.New("table does not exist")
+			}
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+	})
+
+	ctx := context.Background()
+	enforcer := &Enforcer{Name: "enforcer-rbac"}
+	ok, err := client.ReloadEnforcer(ctx, enforcer)
+	if err != nil || !ok {
+		t.Fatalf("Expected the enforcer to be reloaded, got %v, %v", ok, err)
+	}
+	if updated == nil || updated.Model != "built-in/model-rbac" || updated.Adapter != "built-in/adapter-db" {
+		t.Errorf("Expected the stored enforcer to be updated unchanged, but got %+v", updated)
+	}
+
+	policies, err := client.GetPolicies(ctx, enforcer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) != 1 || !reflect.DeepEqual(policies[0].ToArray(), []string{"p", "alice", "data1", "read"}) {
+		t.Errorf("Unexpected policies %+v", policies)
+	}
+
+	oldPolicy := &PermissionRule{Ptype: "p", V0: "alice", V1: "data1", V2: "read"}
+	newPolicy := &PermissionRule{Ptype: "p", V0: "alice", V1: "data1", V2: "write"}
+	for _, modify := range []func() (bool, error){
+		func() (bool, error) { return client.AddPolicy(ctx, enforcer, newPolicy) },
+		func() (bool, error) { return client.UpdatePolicy(ctx, enforcer, oldPolicy, newPolicy) },
+		func() (bool, error) { return client.RemovePolicy(ctx, enforcer, newPolicy) },
+	} {
+		ok, err := modify()
+		if err != nil || !ok {
+			t.Fatalf("Expected the policy to be modified, got %v, %v", ok, err)
+		}
+	}
+	expected := []string{
+		`/api/add-policy {"ptype":"p","v0":"alice","v1":"data1","v2":"write","v3":"","v4":"","v5":""}`,
+		`/api/update-policy [{"ptype":"p","v0":"alice","v1":"data1","v2":"read","v3":"","v4":"","v5":""},{"ptype":"p","v0":"alice","v1":"data1","v2":"write","v3":"","v4":"","v5":""}]`,
+		`/api/remove-policy {"ptype":"p","v0":"alice","v1":"data1","v2":"write","v3":"","v4":"","v5":""}`,
+	}
+	if !reflect.DeepEqual(posts, expected) {
+		t.Errorf("Expected requests %v, but got %v", expected, posts)
+	}
+
+	if err = client.TestAdapterConnection(&Adapter{Owner: "org-adapters", Name: "adapter-db", Table: "casbin_rule"}); err != nil {
+		t.Errorf("Expected no error, but got: %v", err)
+	}
+	if err = client.TestAdapterConnection(&Adapter{Owner: "org-adapters", Name: "adapter-db", Table: "missing"}); err == nil || err.Error() != "table does not exist" {
+		t.Errorf("Expected the error of Casdoor, but got: %v", err)
+	}
+}
@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -69,17 +68,13 @@ func (c *Client) GetPaginationGroups(p int, pageSize int, queryMap map[string]st
 
 	url := c.GetUrl("get-groups", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var groups []*Group
+	total, err := c.doGetPagination(url, &groups)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	groups, ok := response.Data.([]*Group)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return groups, int(response.Data2.(float64)), nil
+	return groups, total, nil
 }
 
 func (c *Client) GetGroup(name string) (*Group, error) {
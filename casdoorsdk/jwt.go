@@ -15,36 +15,347 @@
 package casdoorsdk
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// Token formats an application can issue, see Application.TokenFormat.
+const (
+	TokenFormatJwt         = "JWT"
+	TokenFormatJwtEmpty    = "JWT-Empty"
+	TokenFormatJwtCustom   = "JWT-Custom"
+	TokenFormatJwtStandard = "JWT-Standard"
+)
+
+// UserClaims is implemented by every claims type this SDK can parse, so callers
+// can read the user attributes regardless of the application's token format.
+type UserClaims interface {
+	GetProperties() map[string]string
+	GetRoles() []string
+	GetPermissions() []string
+	GetGroups() []string
+}
+
+// ClaimsMetadata tells which of the trusted certs verified a token.
+type ClaimsMetadata struct {
+	// KeyId is the "kid" header of the token, Casdoor sets it to the name of the signing cert.
+	KeyId string
+	// CertName is the name of the cert that verified the token, empty for a key of AuthConfig.Certificate.
+	CertName string
+	// CertIndex is the position of the key among the trusted ones: the blocks of AuthConfig.Certificate,
+	// then AuthConfig.Certs, then the retired certs.
+	CertIndex int
+	// IsRetired is true when the token was verified by a cert trusted through TrustRetiredCert.
+	IsRetired bool
+}
+
+// Claims is the claims set of the default "JWT" token format, which carries the whole user.
 type Claims struct {
 	User
 	AccessToken string `json:"accessToken"`
 	jwt.RegisteredClaims
+
+	Metadata *ClaimsMetadata `json:"-"`
 }
 
-func (c *Client) ParseJwtToken(token string) (*Claims, error) {
-	t, err := jwt.ParseWithClaims(token, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
+// StandardClaims is the compact claims set of the "JWT-Standard" and "JWT-Empty" token formats.
+type StandardClaims struct {
+	Owner               string `json:"owner,omitempty"`
+	Name                string `json:"name,omitempty"`
+	Id                  string `json:"id,omitempty"`
+	DisplayName         string `json:"displayName,omitempty"`
+	Avatar              string `json:"avatar,omitempty"`
+	Email               string `json:"email,omitempty"`
+	EmailVerified       bool   `json:"email_verified,omitempty"`
+	Phone               string `json:"phone,omitempty"`
+	PhoneNumber         string `json:"phone_number,omitempty"`
+	PhoneNumberVerified bool   `json:"phone_number_verified,omitempty"`
+	Gender              string `json:"gender,omitempty"`
+	Tag                 string `json:"tag,omitempty"`
+	TokenType           string `json:"tokenType,omitempty"`
+	Nonce               string `json:"nonce,omitempty"`
+	Scope               string `json:"scope,omitempty"`
+	Azp                 string `json:"azp,omitempty"`
+	Provider            string `json:"provider,omitempty"`
 
-		publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(c.Certificate))
-		if err != nil {
-			return nil, err
+	Properties  map[string]string `json:"properties,omitempty"`
+	Roles       ClaimNames        `json:"roles,omitempty"`
+	Permissions ClaimNames        `json:"permissions,omitempty"`
+	Groups      ClaimNames        `json:"groups,omitempty"`
+
+	jwt.RegisteredClaims
+
+	Metadata *ClaimsMetadata `json:"-"`
+}
+
+// CustomClaims holds the claims of a "JWT-Custom" token, whose fields depend on
+// the application's token fields, or of any token whose shape is unknown.
+type CustomClaims map[string]interface{}
+
+// ClaimNames is a list of names that decodes both plain strings and objects
+// carrying a "name" field, as roles and permissions are encoded either way.
+type ClaimNames []string
+
+func (n *ClaimNames) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*n = getClaimNames(raw)
+	return nil
+}
+
+func getClaimNames(raw interface{}) []string {
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	names := []string{}
+	for _, item := range items {
+		switch v := item.(type) {
+		case string:
+			names = append(names, v)
+		case map[string]interface{}:
+			if name, ok := v["name"].(string); ok {
+				names = append(names, name)
+			}
 		}
+	}
+	return names
+}
+
+func (c *Claims) GetProperties() map[string]string {
+	return c.Properties
+}
+
+func (c *Claims) GetRoles() []string {
+	names := []string{}
+	for _, role := range c.Roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+func (c *Claims) GetPermissions() []string {
+	names := []string{}
+	for _, permission := range c.Permissions {
+		names = append(names, permission.Name)
+	}
+	return names
+}
+
+func (c *Claims) GetGroups() []string {
+	return c.Groups
+}
+
+func (c *StandardClaims) GetProperties() map[string]string {
+	return c.Properties
+}
+
+func (c *StandardClaims) GetRoles() []string {
+	return c.Roles
+}
+
+func (c *StandardClaims) GetPermissions() []string {
+	return c.Permissions
+}
+
+func (c *StandardClaims) GetGroups() []string {
+	return c.Groups
+}
+
+func (c CustomClaims) Valid() error {
+	return jwt.MapClaims(c).Valid()
+}
+
+func (c CustomClaims) GetProperties() map[string]string {
+	raw, ok := c["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	properties := map[string]string{}
+	for k, v := range raw {
+		properties[k] = fmt.Sprint(v)
+	}
+	return properties
+}
+
+func (c CustomClaims) GetRoles() []string {
+	return getClaimNames(c["roles"])
+}
+
+func (c CustomClaims) GetPermissions() []string {
+	return getClaimNames(c["permissions"])
+}
+
+func (c CustomClaims) GetGroups() []string {
+	return getClaimNames(c["groups"])
+}
 
-		return publicKey, nil
-	})
+// verificationKey is a public key trusted to verify tokens, along with the cert it comes from.
+type verificationKey struct {
+	publicKey crypto.PublicKey
+	metadata  ClaimsMetadata
+}
+
+func (c *Client) ParseJwtToken(token string) (*Claims, error) {
+	t, metadata, err := c.parseJwtToken(token, &Claims{})
 
 	if t != nil {
 		if claims, ok := t.Claims.(*Claims); ok && t.Valid {
+			claims.Metadata = metadata
 			return claims, nil
 		}
 	}
 
 	return nil, err
 }
+
+// ParseJwtTokenInto verifies the token and decodes its claims into claims, which must be a pointer.
+// Types implementing jwt.Claims are validated by their own Valid method, others by the registered claims.
+func (c *Client) ParseJwtTokenInto(token string, claims interface{}) error {
+	if jwtClaims, ok := claims.(jwt.Claims); ok {
+		_, metadata, err := c.parseJwtToken(token, jwtClaims)
+		if err != nil {
+			return err
+		}
+
+		switch v := claims.(type) {
+		case *Claims:
+			v.Metadata = metadata
+		case *StandardClaims:
+			v.Metadata = metadata
+		}
+		return nil
+	}
+
+	_, _, err := c.parseJwtToken(token, jwt.MapClaims{})
+	if err != nil {
+		return err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("token contains an invalid number of segments")
+	}
+
+	payload, err := jwt.DecodeSegment(parts[1])
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, claims)
+}
+
+// parseJwtToken verifies the token with the trusted key named by its "kid" header if there is one,
+// otherwise with each trusted key in turn until one of them matches its signature.
+func (c *Client) parseJwtToken(token string, claims jwt.Claims) (*jwt.Token, *ClaimsMetadata, error) {
+	keys, err := c.getVerificationKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unverified, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return nil, nil, err
+	}
+	keyId, _ := unverified.Header["kid"].(string)
+	keys = selectVerificationKeys(keys, keyId)
+
+	var t *jwt.Token
+	for _, key := range keys {
+		t, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+			return getJwtKey(token, key.publicKey)
+		})
+
+		var validationErr *jwt.ValidationError
+		if err == nil {
+			metadata := key.metadata
+			metadata.KeyId = keyId
+			return t, &metadata, nil
+		}
+		if !errors.As(err, &validationErr) || validationErr.Errors&(jwt.ValidationErrorSignatureInvalid|jwt.ValidationErrorUnverifiable) == 0 {
+			break
+		}
+	}
+
+	return t, nil, err
+}
+
+func (c *Client) getVerificationKeys() ([]*verificationKey, error) {
+	certs, retiredIndex := c.getTrustedCerts()
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate is configured to verify tokens")
+	}
+
+	keys := []*verificationKey{}
+	for i, cert := range certs {
+		publicKeys, err := parsePublicKeysFromPem([]byte(cert.Certificate))
+		if err != nil {
+			if cert.Name != "" {
+				return nil, fmt.Errorf("cert %s: %v", cert.Name, err)
+			}
+			return nil, err
+		}
+
+		for _, publicKey := range publicKeys {
+			keys = append(keys, &verificationKey{
+				publicKey: publicKey,
+				metadata: ClaimsMetadata{
+					CertName:  cert.Name,
+					CertIndex: len(keys),
+					IsRetired: i >= retiredIndex,
+				},
+			})
+		}
+	}
+	return keys, nil
+}
+
+// selectVerificationKeys keeps only the keys of the cert named keyId, if any.
+func selectVerificationKeys(keys []*verificationKey, keyId string) []*verificationKey {
+	if keyId == "" {
+		return keys
+	}
+
+	res := []*verificationKey{}
+	for _, key := range keys {
+		if key.metadata.CertName == keyId {
+			res = append(res, key)
+		}
+	}
+
+	if len(res) == 0 {
+		return keys
+	}
+	return res
+}
+
+func getJwtKey(token *jwt.Token, publicKey crypto.PublicKey) (interface{}, error) {
+	var ok bool
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok = publicKey.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		_, ok = publicKey.(*ecdsa.PublicKey)
+	case *jwt.SigningMethodEd25519:
+		_, ok = publicKey.(ed25519.PublicKey)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return publicKey, nil
+}
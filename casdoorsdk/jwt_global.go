@@ -17,3 +17,9 @@ package casdoorsdk
 func ParseJwtToken(token string) (*Claims, error) {
 	return globalClient.ParseJwtToken(token)
 }
+
+// ParseJwtTokenInto verifies the token and decodes its claims into any claims type,
+// e.g. StandardClaims, CustomClaims or an application-specific struct.
+func ParseJwtTokenInto[T any](token string, claims *T) error {
+	return globalClient.ParseJwtTokenInto(token, claims)
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestParseJwtTokenInto(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))
+	client := NewClient("http://localhost:8000", "", "", certificate, "built-in", "app-built-in")
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"owner":      "built-in",
+		"name":       "alice",
+		"tokenType":  "access-token",
+		"properties": map[string]interface{}{"team": "infra"},
+		"roles":      []interface{}{map[string]interface{}{"owner": "built-in", "name": "admin"}},
+		"groups":     []interface{}{"built-in/dev"},
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}).SignedString(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var standardClaims StandardClaims
+	err = client.ParseJwtTokenInto(token, &standardClaims)
+	if err != nil {
+		t.Fatalf("Failed to parse standard claims: %v", err)
+	}
+
+	var customClaims CustomClaims
+	err = client.ParseJwtTokenInto(token, &customClaims)
+	if err != nil {
+		t.Fatalf("Failed to parse custom claims: %v", err)
+	}
+
+	for _, claims := range []UserClaims{&standardClaims, customClaims} {
+		if !reflect.DeepEqual(claims.GetProperties(), map[string]string{"team": "infra"}) {
+			t.Errorf("Unexpected properties %v", claims.GetProperties())
+		}
+		if !reflect.DeepEqual(claims.GetRoles(), []string{"admin"}) {
+			t.Errorf("Unexpected roles %v", claims.GetRoles())
+		}
+		if !reflect.DeepEqual(claims.GetGroups(), []string{"built-in/dev"}) {
+			t.Errorf("Unexpected groups %v", claims.GetGroups())
+		}
+	}
+
+	expiredToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"name": "alice",
+		"exp":  time.Now().Add(-time.Hour).Unix(),
+	}).SignedString(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expiredClaims struct {
+		Name string `json:"name"`
+	}
+	if err = client.ParseJwtTokenInto(expiredToken, &expiredClaims); err == nil {
+		t.Errorf("Expected expired token to be rejected")
+	}
+}
@@ -0,0 +1,500 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+)
+
+// LocalEnforcerOptions selects the policies a LocalEnforcer evaluates, either those of a
+// permission or those of an enforcer, and how often they are refreshed.
+type LocalEnforcerOptions struct {
+	// PermissionId is the id ("owner/name") of the permission to enforce.
+	PermissionId string
+	// EnforcerId is the id ("owner/name") of the enforcer to enforce, used when PermissionId is empty.
+	EnforcerId string
+	// RefreshInterval reloads the policies periodically, 0 disables the periodic refresh.
+	RefreshInterval time.Duration
+	// MaxStaleness is how old the local snapshot may be before requests are enforced remotely,
+	// 0 means the snapshot never becomes stale.
+	MaxStaleness time.Duration
+}
+
+// LocalEnforcer evaluates requests in-process with Casbin, from a snapshot of the model and
+// policies downloaded from Casdoor. Requests are enforced remotely while the snapshot is missing or stale.
+type LocalEnforcer struct {
+	client  *Client
+	options LocalEnforcerOptions
+
+	mutex      sync.RWMutex
+	enforcer   *casbin.Enforcer
+	rules      []*PermissionRule
+	loadedTime time.Time
+	lastError  error
+
+	refreshing sync.Mutex
+	notify     chan struct{}
+	stop       chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewLocalEnforcer loads the snapshot and starts refreshing it in the background.
+// A failed initial load is not fatal: requests are enforced remotely until a refresh succeeds, see LastError.
+func (c *Client) NewLocalEnforcer(options LocalEnforcerOptions) (*LocalEnforcer, error) {
+	if options.PermissionId == "" && options.EnforcerId == "" {
+		return nil, errors.New("either a permission id or an enforcer id is required")
+	}
+
+	e := &LocalEnforcer{
+		client:  c,
+		options: options,
+		notify:  make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+	}
+	_ = e.Refresh()
+
+	go e.run()
+	return e, nil
+}
+
+func (e *LocalEnforcer) run() {
+	var tick <-chan time.Time
+	if e.options.RefreshInterval > 0 {
+		ticker := time.NewTicker(e.options.RefreshInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-tick:
+		case <-e.notify:
+		}
+		_ = e.Refresh()
+	}
+}
+
+// Close stops the background refresh.
+func (e *LocalEnforcer) Close() {
+	e.closeOnce.Do(func() {
+		close(e.stop)
+	})
+}
+
+// Notify schedules a refresh without waiting for it, e.g. when Casdoor reports a policy change.
+func (e *LocalEnforcer) Notify() {
+	select {
+	case e.notify <- struct{}{}:
+	default:
+	}
+}
+
+// ServeHTTP lets the enforcer be registered as the endpoint of a Casdoor webhook,
+// every notification schedules a refresh.
+func (e *LocalEnforcer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	e.Notify()
+	w.WriteHeader(http.StatusOK)
+}
+
+// LoadedTime returns when the snapshot was last loaded, the zero time if it never was.
+func (e *LocalEnforcer) LoadedTime() time.Time {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.loadedTime
+}
+
+// LastError returns the error of the last refresh, nil if it succeeded.
+func (e *LocalEnforcer) LastError() error {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.lastError
+}
+
+// Refresh downloads the model and the policies and replaces the snapshot.
+// The previous snapshot is kept if the download fails.
+func (e *LocalEnforcer) Refresh() error {
+	e.refreshing.Lock()
+	defer e.refreshing.Unlock()
+
+	enforcer, rules, err := e.load()
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.lastError = err
+	if err != nil {
+		return err
+	}
+
+	e.enforcer = enforcer
+	e.rules = rules
+	e.loadedTime = time.Now()
+	return nil
+}
+
+func (e *LocalEnforcer) load() (*casbin.Enforcer, []*PermissionRule, error) {
+	var modelText string
+	var rules []*PermissionRule
+	var err error
+	if e.options.PermissionId != "" {
+		modelText, rules, err = e.client.getPermissionSnapshot(context.Background(), e.options.PermissionId)
+	} else {
+		modelText, rules, err = e.client.getEnforcerSnapshot(context.Background(), e.options.EnforcerId)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enforcer, err := newCasbinEnforcer(modelText, rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enforcer, rules, nil
+}
+
+// getSnapshot returns the local enforcer and the rules it was built from if its snapshot is usable, nil otherwise.
+func (e *LocalEnforcer) getSnapshot() (*casbin.Enforcer, []*PermissionRule) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	if e.enforcer == nil {
+		return nil, nil
+	}
+	if e.options.MaxStaleness > 0 && time.Since(e.loadedTime) > e.options.MaxStaleness {
+		return nil, nil
+	}
+	return e.enforcer, e.rules
+}
+
+// Enforce evaluates the request locally, or remotely when the snapshot is missing or stale.
+func (e *LocalEnforcer) Enforce(casbinRequest CasbinRequest) (bool, error) {
+	enforcer, _ := e.getSnapshot()
+	if enforcer == nil {
+		results, err := e.enforceRemotely("enforce", casbinRequest)
+		if err != nil {
+			return false, err
+		}
+
+		for _, isAllow := range results {
+			if isAllow {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	return enforcer.Enforce(casbinRequest...)
+}
+
+// BatchEnforce evaluates the requests locally, or remotely when the snapshot is missing or stale.
+// It returns one result per request.
+func (e *LocalEnforcer) BatchEnforce(casbinRequests []CasbinRequest) ([]bool, error) {
+	enforcer, _ := e.getSnapshot()
+	if enforcer == nil {
+		return e.batchEnforceRemotely(casbinRequests)
+	}
+
+	return enforcer.BatchEnforce(casbinRequests)
+}
+
+func (e *LocalEnforcer) enforceRemotely(action string, request interface{}) ([]bool, error) {
+	postBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.client.doPostWithContext(context.Background(), action, e.getQueryMap(), postBytes, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if action == "enforce" {
+		return parseEnforceResults(res.Data)
+	}
+
+	data, ok := res.Data.([]interface{})
+	if !ok || len(data) == 0 {
+		return nil, errors.New("invalid data")
+	}
+	return parseEnforceResults(data[0])
+}
+
+// getQueryMap returns the query selecting the policies of the enforcer in the enforce APIs.
+func (e *LocalEnforcer) getQueryMap() map[string]string {
+	if e.options.PermissionId == "" {
+		return map[string]string{
+			"enforcerId": e.options.EnforcerId,
+		}
+	}
+
+	return map[string]string{
+		"permissionId": e.options.PermissionId,
+	}
+}
+
+func (e *LocalEnforcer) batchEnforceRemotely(casbinRequests []CasbinRequest) ([]bool, error) {
+	results, err := e.enforceRemotely("batch-enforce", casbinRequests)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(casbinRequests) {
+		return nil, errors.New("invalid data")
+	}
+	return results, nil
+}
+
+// newCasbinEnforcer builds an in-memory Casbin enforcer. Rules are cut or padded to the
+// length of their policy definition, as Casdoor stores extra values such as the permission id.
+func newCasbinEnforcer(modelText string, rules []*PermissionRule) (*casbin.Enforcer, error) {
+	m, err := model.NewModelFromString(modelText)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcer, err := casbin.NewEnforcer(m)
+	if err != nil {
+		return nil, err
+	}
+
+	m = enforcer.GetModel()
+	for _, rule := range rules {
+		if rule.Ptype == "" {
+			continue
+		}
+		assertion, ok := m[rule.Ptype[:1]][rule.Ptype]
+		if !ok {
+			continue
+		}
+
+		line := rule.ToArray()
+		values := make([]string, len(assertion.Tokens))
+		copy(values, line[1:])
+		err = persist.LoadPolicyArray(append([]string{rule.Ptype}, values...), m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = enforcer.BuildRoleLinks()
+	if err != nil {
+		return nil, err
+	}
+	return enforcer, nil
+}
+
+func (c *Client) getEnforcerSnapshot(ctx context.Context, enforcerId string) (string, []*PermissionRule, error) {
+	queryMap := map[string]string{
+		"id": enforcerId,
+	}
+
+	var enforcer *Enforcer
+	err := c.doGetWithContext(ctx, "get-enforcer", queryMap, &enforcer)
+	if err != nil {
+		return "", nil, err
+	}
+	if enforcer == nil {
+		return "", nil, fmt.Errorf("enforcer %s does not exist", enforcerId)
+	}
+
+	modelText, err := c.getModelText(ctx, getFullId(enforcer.Owner, enforcer.Model))
+	if err != nil {
+		return "", nil, err
+	}
+
+	rules, err := c.getPolicies(ctx, enforcerId)
+	if err != nil {
+		return "", nil, err
+	}
+	return modelText, rules, nil
+}
+
+func (c *Client) getPermissionSnapshot(ctx context.Context, permissionId string) (string, []*PermissionRule, error) {
+	queryMap := map[string]string{
+		"id": permissionId,
+	}
+
+	var permission *Permission
+	err := c.doGetWithContext(ctx, "get-permission", queryMap, &permission)
+	if err != nil {
+		return "", nil, err
+	}
+	if permission == nil {
+		return "", nil, fmt.Errorf("permission %s does not exist", permissionId)
+	}
+
+	modelText, err := c.getModelText(ctx, getFullId(permission.Owner, permission.Model))
+	if err != nil {
+		return "", nil, err
+	}
+
+	queryMap = map[string]string{
+		"owner": permission.Owner,
+	}
+
+	var roles []*Role
+	err = c.doGetWithContext(ctx, "get-roles", queryMap, &roles)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the members of groups are only needed when the permission or its roles grant groups
+	var users []*User
+	var groups []*Group
+	if len(getPermissionGroupIds(permission, roles)) != 0 {
+		err = c.doGetWithContext(ctx, "get-users", queryMap, &users)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = c.doGetWithContext(ctx, "get-groups", queryMap, &groups)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return modelText, getPermissionRules(permission, roles, users, groups), nil
+}
+
+// getPermissionRoles returns the roles of the permission and the roles they contain, recursively.
+func getPermissionRoles(permission *Permission, roles []*Role) []*Role {
+	roleMap := map[string]*Role{}
+	for _, role := range roles {
+		roleMap[getFullId(role.Owner, role.Name)] = role
+	}
+
+	res := []*Role{}
+	visited := map[string]bool{}
+	var addRole func(roleId string)
+	addRole = func(roleId string) {
+		if visited[roleId] {
+			return
+		}
+		visited[roleId] = true
+
+		role, ok := roleMap[roleId]
+		if !ok {
+			return
+		}
+
+		res = append(res, role)
+		for _, subRoleId := range role.Roles {
+			addRole(subRoleId)
+		}
+	}
+	for _, roleId := range permission.Roles {
+		addRole(roleId)
+	}
+	return res
+}
+
+// getPermissionGroupIds returns the groups granted the permission, directly or through its roles.
+func getPermissionGroupIds(permission *Permission, roles []*Role) []string {
+	res := append([]string{}, permission.Groups...)
+	for _, role := range getPermissionRoles(permission, roles) {
+		for _, groupId := range role.Groups {
+			if !containsString(res, groupId) {
+				res = append(res, groupId)
+			}
+		}
+	}
+	return res
+}
+
+// getPermissionRules generates the policy rules of a permission the same way Casdoor does:
+// p rules for every subject, domain, resource and action, g rules for the members of its roles.
+// Groups are expanded like in GetUserEffectivePermissions, with g rules from the users of a group
+// or of its subgroups to the group.
+func getPermissionRules(permission *Permission, roles []*Role, users []*User, groups []*Group) []*PermissionRule {
+	permissionId := getFullId(permission.Owner, permission.Name)
+	effect := strings.ToLower(permission.Effect)
+	if effect == "" {
+		effect = "allow"
+	}
+
+	rules := []*PermissionRule{}
+	addGroupingRule := func(member string, roleId string) {
+		if len(permission.Domains) == 0 {
+			rules = append(rules, &PermissionRule{Ptype: "g", V0: member, V1: roleId, V5: permissionId})
+			return
+		}
+
+		for _, domain := range permission.Domains {
+			rules = append(rules, &PermissionRule{Ptype: "g", V0: member, V1: roleId, V2: domain, V5: permissionId})
+		}
+	}
+
+	subjects := append(append(append([]string{}, permission.Users...), permission.Groups...), permission.Roles...)
+	for _, subject := range subjects {
+		for _, resource := range permission.Resources {
+			for _, action := range permission.Actions {
+				action = strings.ToLower(action)
+				if len(permission.Domains) == 0 {
+					rules = append(rules, &PermissionRule{Ptype: "p", V0: subject, V1: resource, V2: action, V3: effect, V5: permissionId})
+					continue
+				}
+
+				for _, domain := range permission.Domains {
+					rules = append(rules, &PermissionRule{Ptype: "p", V0: subject, V1: domain, V2: resource, V3: action, V4: effect, V5: permissionId})
+				}
+			}
+		}
+	}
+
+	for _, role := range getPermissionRoles(permission, roles) {
+		roleId := getFullId(role.Owner, role.Name)
+		for _, member := range append(append(append([]string{}, role.Users...), role.Groups...), role.Roles...) {
+			addGroupingRule(member, roleId)
+		}
+	}
+
+	groupIds := getPermissionGroupIds(permission, roles)
+	for _, user := range users {
+		userId := getFullId(user.Owner, user.Name)
+		for _, groupId := range getUserGroupIds(user, groups) {
+			if containsString(groupIds, groupId) {
+				addGroupingRule(userId, groupId)
+			}
+		}
+	}
+
+	return rules
+}
+
+// getFullId returns name as is if it already is an id ("owner/name"), otherwise prefixes it with owner.
+func getFullId(owner string, name string) string {
+	if strings.Contains(name, "/") {
+		return name
+	}
+	return fmt.Sprintf("%s/%s", owner, name)
+}
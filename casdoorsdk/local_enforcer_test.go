@@ -0,0 +1,366 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testModelText = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act`
+
+func TestPermissionRulesEnforcement(t *testing.T) {
+	permission := &Permission{
+		Owner:     "built-in",
+		Name:      "permission-data",
+		Users:     []string{"built-in/alice"},
+		Roles:     []string{"built-in/admin"},
+		Resources: []string{"data1"},
+		Actions:   []string{"Read"},
+		Effect:    "Allow",
+		Model:     "model-rbac",
+	}
+	roles := []*Role{
+		{Owner: "built-in", Name: "admin", Roles: []string{"built-in/operator"}},
+		{Owner: "built-in", Name: "operator", Users: []string{"built-in/bob"}},
+	}
+
+	enforcer, err := newCasbinEnforcer(testModelText, getPermissionRules(permission, roles, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		request  CasbinRequest
+		expected bool
+	}{
+		{request: CasbinRequest{"built-in/alice", "data1", "read"}, expected: true},
+		{request: CasbinRequest{"built-in/bob", "data1", "read"}, expected: true},
+		{request: CasbinRequest{"built-in/bob", "data1", "write"}, expected: false},
+		{request: CasbinRequest{"built-in/carol", "data1", "read"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		allowed, err := enforcer.Enforce(tc.request...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != tc.expected {
+			t.Errorf("For request %v, expected %v, but got %v", tc.request, tc.expected, allowed)
+		}
+	}
+}
+
+func TestExplainRequest(t *testing.T) {
+	permission := &Permission{
+		Owner:     "built-in",
+		Name:      "permission-data",
+		Roles:     []string{"built-in/admin"},
+		Resources: []string{"data1"},
+		Actions:   []string{"Read"},
+		Effect:    "Allow",
+	}
+	roles := []*Role{
+		{Owner: "built-in", Name: "admin", Users: []string{"built-in/bob"}},
+	}
+	rules := getPermissionRules(permission, roles, nil, nil)
+
+	enforcer, err := newCasbinEnforcer(testModelText, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowed, matchedRules, err := explainRequest(enforcer, rules, CasbinRequest{"built-in/bob", "data1", "read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !allowed || len(matchedRules) != 1 || matchedRules[0].V0 != "built-in/admin" || matchedRules[0].V5 != "built-in/permission-data" {
+		t.Errorf("Unexpected explanation %v, %+v", allowed, matchedRules)
+	}
+
+	allowed, matchedRules, err = explainRequest(enforcer, rules, CasbinRequest{"built-in/bob", "data1", "write"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowed || len(matchedRules) != 0 {
+		t.Errorf("Expected no matched rule for a denied request, got %v, %+v", allowed, matchedRules)
+	}
+}
+
+func TestPermissionRulesGroups(t *testing.T) {
+	permission := &Permission{
+		Owner:     "built-in",
+		Name:      "permission-data",
+		Groups:    []string{"built-in/dev"},
+		Roles:     []string{"built-in/admin"},
+		Resources: []string{"data1"},
+		Actions:   []string{"Read"},
+		Effect:    "Allow",
+	}
+	roles := []*Role{
+		{Owner: "built-in", Name: "admin", Groups: []string{"built-in/ops"}},
+	}
+	users := []*User{
+		{Owner: "built-in", Name: "alice", Groups: []string{"built-in/dev"}},
+		{Owner: "built-in", Name: "bob", Groups: []string{"ops-oncall"}},
+		{Owner: "built-in", Name: "carol", Groups: []string{"built-in/sales"}},
+	}
+	groups := []*Group{
+		{Owner: "built-in", Name: "dev", ParentId: "built-in", IsTopGroup: true},
+		{Owner: "built-in", Name: "ops", ParentId: "built-in", IsTopGroup: true},
+		{Owner: "built-in", Name: "ops-oncall", ParentId: "ops"},
+		{Owner: "built-in", Name: "sales", ParentId: "built-in", IsTopGroup: true},
+	}
+
+	enforcer, err := newCasbinEnforcer(testModelText, getPermissionRules(permission, roles, users, groups))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		request  CasbinRequest
+		expected bool
+	}{
+		{request: CasbinRequest{"built-in/alice", "data1", "read"}, expected: true},
+		{request: CasbinRequest{"built-in/bob", "data1", "read"}, expected: true},
+		{request: CasbinRequest{"built-in/carol", "data1", "read"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		allowed, err := enforcer.Enforce(tc.request...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != tc.expected {
+			t.Errorf("For request %v, expected %v, but got %v", tc.request, tc.expected, allowed)
+		}
+	}
+}
+
+// fakePermissionServer serves a permission and enforces remotely by allowing every request.
+type fakePermissionServer struct {
+	mutex       sync.Mutex
+	permission  *Permission
+	unavailable bool
+	remoteCalls int
+	loads       int
+}
+
+func (s *fakePermissionServer) handle(r *http.Request) (interface{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch r.URL.Path {
+	case "/api/enforce":
+		s.remoteCalls++
+		return []bool{true}, nil
+	case "/api/batch-enforce":
+		s.remoteCalls++
+		return [][]bool{{true, true}}, nil
+	}
+
+	if s.unavailable {
+		return nil, errors.New("service unavailable")
+	}
+	switch r.URL.Path {
+	case "/api/get-permission":
+		s.loads++
+		return s.permission, nil
+	case "/api/get-model":
+		return &Model{Owner: "built-in", Name: "model-rbac", ModelText: testModelText}, nil
+	case "/api/get-roles":
+		return []*Role{}, nil
+	}
+	return nil, fmt.Errorf("unexpected request %s", r.URL.Path)
+}
+
+func (s *fakePermissionServer) getRemoteCalls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.remoteCalls
+}
+
+func newFakePermissionServer(t *testing.T, options LocalEnforcerOptions, unavailable bool) (*fakePermissionServer, *LocalEnforcer) {
+	server := &fakePermissionServer{
+		permission: &Permission{
+			Owner:     "built-in",
+			Name:      "permission-data",
+			Users:     []string{"built-in/alice"},
+			Resources: []string{"data1"},
+			Actions:   []string{"Read"},
+			Effect:    "Allow",
+			Model:     "model-rbac",
+		},
+		unavailable: unavailable,
+	}
+
+	options.PermissionId = "built-in/permission-data"
+	e, err := newFakeClient(t, server.handle).NewLocalEnforcer(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(e.Close)
+	return server, e
+}
+
+func TestLocalEnforcerRemoteFallback(t *testing.T) {
+	server, e := newFakePermissionServer(t, LocalEnforcerOptions{}, true)
+	if e.LastError() == nil || !e.LoadedTime().IsZero() {
+		t.Fatal("expected the initial load to fail")
+	}
+
+	allowed, err := e.Enforce(CasbinRequest{"built-in/bob", "data1", "read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !allowed || server.getRemoteCalls() != 1 {
+		t.Errorf("expected the request to be enforced remotely, but got %v after %d remote calls", allowed, server.getRemoteCalls())
+	}
+
+	server.mutex.Lock()
+	server.unavailable = false
+	server.mutex.Unlock()
+	err = e.Refresh()
+	if err != nil || e.LastError() != nil {
+		t.Fatalf("expected the refresh to succeed, but got %v", err)
+	}
+
+	results, err := e.BatchEnforce([]CasbinRequest{{"built-in/alice", "data1", "read"}, {"built-in/bob", "data1", "read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || !results[0] || results[1] || server.getRemoteCalls() != 1 {
+		t.Errorf("expected the requests to be enforced locally, but got %v after %d remote calls", results, server.getRemoteCalls())
+	}
+}
+
+func TestLocalEnforcerStaleness(t *testing.T) {
+	server, e := newFakePermissionServer(t, LocalEnforcerOptions{MaxStaleness: time.Minute}, false)
+
+	allowed, err := e.Enforce(CasbinRequest{"built-in/bob", "data1", "read"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowed || server.getRemoteCalls() != 0 {
+		t.Errorf("expected a fresh snapshot to be used, but got %v after %d remote calls", allowed, server.getRemoteCalls())
+	}
+
+	e.mutex.Lock()
+	e.loadedTime = time.Now().Add(-2 * time.Minute)
+	e.mutex.Unlock()
+
+	results, err := e.BatchEnforce([]CasbinRequest{{"built-in/alice", "data1", "read"}, {"built-in/bob", "data1", "read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || !results[1] || server.getRemoteCalls() != 1 {
+		t.Errorf("expected a stale snapshot to be bypassed, but got %v after %d remote calls", results, server.getRemoteCalls())
+	}
+}
+
+func TestLocalEnforcerServeHTTP(t *testing.T) {
+	server, e := newFakePermissionServer(t, LocalEnforcerOptions{}, false)
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/casdoor-webhook", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d for a GET, but got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+
+	server.mutex.Lock()
+	server.permission.Users = append(server.permission.Users, "built-in/bob")
+	server.mutex.Unlock()
+
+	recorder = httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/casdoor-webhook", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d for a POST, but got %d", http.StatusOK, recorder.Code)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		allowed, err := e.Enforce(CasbinRequest{"built-in/bob", "data1", "read"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the webhook notification to refresh the policies")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if server.getRemoteCalls() != 0 {
+		t.Errorf("expected the requests to be enforced locally, but got %d remote calls", server.getRemoteCalls())
+	}
+}
+
+func TestEnforceExReusesSnapshot(t *testing.T) {
+	server := &fakePermissionServer{
+		permission: &Permission{
+			Owner:     "built-in",
+			Name:      "permission-data",
+			Users:     []string{"built-in/alice"},
+			Resources: []string{"data1"},
+			Actions:   []string{"Read"},
+			Effect:    "Allow",
+			Model:     "model-rbac",
+		},
+	}
+	client := newFakeClient(t, server.handle)
+
+	for i := 0; i < 3; i++ {
+		result, err := client.EnforceEx(context.Background(), "built-in/permission-data", "", "", CasbinRequest{"built-in/alice", "data1", "read"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Allowed || result.PermissionId != "built-in/permission-data" || len(result.MatchedRules) != 1 {
+			t.Errorf("Unexpected result %+v", result)
+		}
+	}
+	if server.loads != 1 || server.getRemoteCalls() != 3 {
+		t.Errorf("Expected the permission to be downloaded once for 3 remote calls, got %d downloads and %d calls", server.loads, server.getRemoteCalls())
+	}
+
+	cached, _ := client.permissionSnapshots.Load("built-in/permission-data")
+	cached.(*cachedPermissionSnapshot).fetchedTime = time.Now().Add(-permissionSnapshotTTL)
+	if _, err := client.EnforceEx(context.Background(), "built-in/permission-data", "", "", CasbinRequest{"built-in/alice", "data1", "read"}); err != nil {
+		t.Fatal(err)
+	}
+	if server.loads != 2 {
+		t.Errorf("Expected an expired snapshot to be downloaded again, got %d downloads", server.loads)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// The MFA types of Casdoor, MfaProps.MfaType and User.PreferredMfaType.
+const (
+	MfaTypeSms   = "sms"
+	MfaTypeEmail = "email"
+	MfaTypeTotp  = "app"
+)
+
+// postMfaForm posts the form to the MFA action and decodes the data of the response into v, unless v is nil.
+func (c *Client) postMfaForm(ctx context.Context, action string, form map[string]string, v interface{}) error {
+	postBytes, err := json.Marshal(form)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doPostWithContext(ctx, action, nil, postBytes, true, false)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+
+	dataBytes, err := json.Marshal(resp.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(dataBytes, v)
+}
+
+// getMfaDest returns the email or the phone of the user the codes of the MFA type are sent to, none for TOTP.
+func getMfaDest(user *User, mfaType string) (string, string) {
+	switch mfaType {
+	case MfaTypeSms:
+		return user.Phone, user.CountryCode
+	case MfaTypeEmail:
+		return user.Email, ""
+	}
+	return "", ""
+}
+
+// InitiateMfa starts setting up the MFA type for the user. For MfaTypeTotp, Casdoor generates the secret and its
+// otpauth URL to show as a QR code. The returned props have the recovery code to show to the user, and are
+// passed to VerifyMfaSetup and EnableMfa.
+func (c *Client) InitiateMfa(ctx context.Context, user *User, mfaType string) (*MfaProps, error) {
+	form := map[string]string{
+		"owner":   user.Owner,
+		"name":    user.Name,
+		"mfaType": mfaType,
+	}
+
+	var props MfaProps
+	err := c.postMfaForm(ctx, "mfa/setup/initiate", form, &props)
+	if err != nil {
+		return nil, err
+	}
+	props.MfaType = mfaType
+	return &props, nil
+}
+
+// VerifyMfaSetup checks the passcode entered by the user while setting up MFA: the code of the authenticator app
+// for MfaTypeTotp, or the code sent with SendVerificationCode and VerificationMethodMfaSetup otherwise.
+func (c *Client) VerifyMfaSetup(ctx context.Context, user *User, props *MfaProps, passcode string) error {
+	dest, countryCode := getMfaDest(user, props.MfaType)
+	form := map[string]string{
+		"mfaType":     props.MfaType,
+		"passcode":    passcode,
+		"secret":      props.Secret,
+		"dest":        dest,
+		"countryCode": countryCode,
+	}
+	return c.postMfaForm(ctx, "mfa/setup/verify", form, nil)
+}
+
+// EnableMfa enables the MFA type for the user once its setup is verified. Casdoor keeps the first recovery code
+// of the props, which the user can sign in with when the factor is lost.
+func (c *Client) EnableMfa(ctx context.Context, user *User, props *MfaProps) error {
+	dest, countryCode := getMfaDest(user, props.MfaType)
+	recoveryCode := ""
+	if len(props.RecoveryCodes) != 0 {
+		recoveryCode = props.RecoveryCodes[0]
+	}
+
+	form := map[string]string{
+		"owner":         user.Owner,
+		"name":          user.Name,
+		"mfaType":       props.MfaType,
+		"secret":        props.Secret,
+		"dest":          dest,
+		"countryCode":   countryCode,
+		"recoveryCodes": recoveryCode,
+	}
+	return c.postMfaForm(ctx, "mfa/setup/enable", form, nil)
+}
+
+// DisableMfa disables all the MFA types of the user, and clears its TOTP secret and recovery codes.
+func (c *Client) DisableMfa(ctx context.Context, user *User) error {
+	form := map[string]string{
+		"owner": user.Owner,
+		"name":  user.Name,
+	}
+	err := c.postMfaForm(ctx, "delete-mfa", form, nil)
+	if err != nil {
+		return err
+	}
+
+	user.PreferredMfaType = ""
+	user.TotpSecret = ""
+	user.RecoveryCodes = nil
+	user.MfaPhoneEnabled = false
+	user.MfaEmailEnabled = false
+	return nil
+}
+
+// SetPreferredMfa sets the enabled MFA type asked first when the user signs in, and returns the MFA types of the user.
+func (c *Client) SetPreferredMfa(ctx context.Context, user *User, mfaType string) ([]*MfaProps, error) {
+	form := map[string]string{
+		"owner":   user.Owner,
+		"name":    user.Name,
+		"mfaType": mfaType,
+	}
+
+	var props []*MfaProps
+	err := c.postMfaForm(ctx, "set-preferred-mfa", form, &props)
+	if err != nil {
+		return nil, err
+	}
+	user.PreferredMfaType = mfaType
+	return props, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user, which must have MFA enabled, with a new one
+// generated like Casdoor does, and returns it to show to the user. The user is read again from Casdoor first,
+// so that MFA is checked and the codes are written on its current state.
+func (c *Client) RegenerateRecoveryCodes(ctx context.Context, user *User) ([]string, error) {
+	var current *User
+	err := c.doGetWithContext(ctx, "get-user", map[string]string{"id": getFullId(user.Owner, user.Name)}, &current)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("the user %s does not exist", user.Name)
+	}
+	if current.PreferredMfaType == "" {
+		return nil, fmt.Errorf("the user %s has no MFA enabled", user.Name)
+	}
+
+	recoveryCodes := []string{uuid.NewString()}
+	current.RecoveryCodes = recoveryCodes
+	_, affected, err := c.modifyUserWithContext(ctx, "update-user", current, []string{"recovery_codes"})
+	if err != nil {
+		return nil, err
+	}
+	if !affected {
+		return nil, fmt.Errorf("the recovery codes of user %s are not updated", user.Name)
+	}
+
+	user.RecoveryCodes = recoveryCodes
+	return recoveryCodes, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import "context"
+
+func InitiateMfa(ctx context.Context, user *User, mfaType string) (*MfaProps, error) {
+	return globalClient.InitiateMfa(ctx, user, mfaType)
+}
+
+func VerifyMfaSetup(ctx context.Context, user *User, props *MfaProps, passcode string) error {
+	return globalClient.VerifyMfaSetup(ctx, user, props, passcode)
+}
+
+func EnableMfa(ctx context.Context, user *User, props *MfaProps) error {
+	return globalClient.EnableMfa(ctx, user, props)
+}
+
+func DisableMfa(ctx context.Context, user *User) error {
+	return globalClient.DisableMfa(ctx, user)
+}
+
+func SetPreferredMfa(ctx context.Context, user *User, mfaType string) ([]*MfaProps, error) {
+	return globalClient.SetPreferredMfa(ctx, user, mfaType)
+}
+
+func RegenerateRecoveryCodes(ctx context.Context, user *User) ([]string, error) {
+	return globalClient.RegenerateRecoveryCodes(ctx, user)
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTotp(t *testing.T) {
+	// the test vectors of https://www.rfc-editor.org/rfc/rfc6238#appendix-B for SHA1, truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	testCases := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tc := range testCases {
+		code, err := GenerateTotpCode(secret, time.Unix(tc.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != tc.expected {
+			t.Errorf("At %d, expected code %s, but got %s", tc.unix, tc.expected, code)
+		}
+	}
+
+	now := time.Unix(1111111111, 0)
+	if !ValidateTotpCode(secret, "050471", now.Add(30*time.Second)) {
+		t.Errorf("Expected the code of the previous period to be valid")
+	}
+	if ValidateTotpCode(secret, "050471", now.Add(90*time.Second)) {
+		t.Errorf("Expected the code of an older period to be invalid")
+	}
+
+	generated, err := GenerateTotpSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, _ := GenerateTotpCode(generated, now)
+	if len(generated) != 32 || !ValidateTotpCode(generated, code, now) {
+		t.Errorf("Unexpected generated secret %s", generated)
+	}
+	if _, err = GenerateTotpCode("not base32!", now); err == nil {
+		t.Errorf("Expected an invalid secret to be rejected")
+	}
+
+	expected := "otpauth://totp/built-in:alice?algorithm=SHA1&digits=6&issuer=built-in&period=30&secret=" + secret
+	if url := GetTotpUrl("built-in", "alice", secret); url != expected {
+		t.Errorf("Expected URL %s, but got %s", expected, url)
+	}
+}
+
+func TestMfaLifecycle(t *testing.T) {
+	var calls []string
+	var enabled map[string]string
+	var updated *User
+	// the users as stored in Casdoor
+	users := map[string]*User{
+		"alice": {Owner: "built-in", Name: "alice", Email: "alice@example.com"},
+		"bob":   {Owner: "built-in", Name: "bob", PreferredMfaType: MfaTypeTotp},
+		"carol": {Owner: "built-in", Name: "carol"},
+	}
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		calls = append(calls, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/get-user":
+			return users[strings.TrimPrefix(r.URL.Query().Get("id"), "built-in/")], nil
+		case "/api/mfa/setup/initiate":
+			return MfaProps{MfaType: r.FormValue("mfaType"), Secret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", RecoveryCodes: []string{"recovery-code"}}, nil
+		case "/api/mfa/setup/verify":
+			if !ValidateTotpCode(r.FormValue("secret"), r.FormValue("passcode"), time.Now()) {
+				return nil, errors.New("totp passcode error")
+			}
+		case "/api/mfa/setup/enable":
+			enabled = map[string]string{}
+			for _, key := range []string{"owner", "name", "mfaType", "secret", "recoveryCodes"} {
+				enabled[key] = r.FormValue(key)
+			}
+		case "/api/set-preferred-mfa":
+			users[r.FormValue("name")].PreferredMfaType = r.FormValue("mfaType")
+			return []*MfaProps{{Enabled: true, IsPreferred: true, MfaType: r.FormValue("mfaType")}}, nil
+		case "/api/update-user":
+			if r.URL.Query().Get("id") == "built-in/bob" {
+				return "Not affected", nil
+			}
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			return "Affected", nil
+		case "/api/delete-mfa":
+			users[r.FormValue("name")].PreferredMfaType = ""
+		}
+		return nil, nil
+	})
+	ctx := context.Background()
+	user := &User{Owner: "built-in", Name: "alice"}
+
+	props, err := client.InitiateMfa(ctx, user, MfaTypeTotp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.VerifyMfaSetup(ctx, user, props, "000000"); err == nil {
+		t.Errorf("Expected a wrong passcode to be rejected")
+	}
+	code, _ := GenerateTotpCode(props.Secret, time.Now())
+	if err = client.VerifyMfaSetup(ctx, user, props, code); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.EnableMfa(ctx, user, props); err != nil {
+		t.Fatal(err)
+	}
+	if enabled["name"] != "alice" || enabled["mfaType"] != MfaTypeTotp || enabled["secret"] != props.Secret || enabled["recoveryCodes"] != "recovery-code" {
+		t.Errorf("Unexpected enable form %v", enabled)
+	}
+
+	mfas, err := client.SetPreferredMfa(ctx, user, MfaTypeTotp)
+	if err != nil || len(mfas) != 1 || !mfas[0].IsPreferred || user.PreferredMfaType != MfaTypeTotp {
+		t.Errorf("Unexpected preferred MFA %v, %v", mfas, err)
+	}
+
+	recoveryCodes, err := client.RegenerateRecoveryCodes(ctx, user)
+	if err != nil || len(recoveryCodes) != 1 || recoveryCodes[0] == "recovery-code" || user.RecoveryCodes[0] != recoveryCodes[0] {
+		t.Errorf("Unexpected recovery codes %v, %v", recoveryCodes, err)
+	}
+	if updated == nil || updated.Email != "alice@example.com" || updated.RecoveryCodes[0] != recoveryCodes[0] {
+		t.Errorf("Expected the recovery codes to be written on the stored user, got %+v", updated)
+	}
+
+	if err = client.DisableMfa(ctx, user); err != nil || user.PreferredMfaType != "" || user.RecoveryCodes != nil {
+		t.Errorf("Expected MFA to be disabled, got %v", err)
+	}
+	if _, err = client.RegenerateRecoveryCodes(ctx, user); err == nil {
+		t.Errorf("Expected recovery codes to require MFA")
+	}
+
+	if len(calls) != 9 || calls[len(calls)-2] != "/api/delete-mfa" {
+		t.Errorf("Unexpected calls %v", calls)
+	}
+
+	// MFA is checked on the stored user, not on the given copy
+	carol := &User{Owner: "built-in", Name: "carol", PreferredMfaType: MfaTypeTotp}
+	if _, err = client.RegenerateRecoveryCodes(ctx, carol); err == nil {
+		t.Errorf("Expected recovery codes to require MFA in Casdoor")
+	}
+
+	bob := &User{Owner: "built-in", Name: "bob", RecoveryCodes: []string{"recovery-code"}}
+	if _, err = client.RegenerateRecoveryCodes(ctx, bob); err == nil || bob.RecoveryCodes[0] != "recovery-code" {
+		t.Errorf("Expected the recovery codes to be kept when the user is not updated, got %v", err)
+	}
+}
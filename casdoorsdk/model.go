@@ -15,31 +15,22 @@
 package casdoorsdk
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
 
+// Model has the same definition as https://github.com/casdoor/casdoor/blob/master/object/model.go#L25
 type Model struct {
 	Owner       string `xorm:"varchar(100) notnull pk" json:"owner"`
-	Name        string `xorm:"varchar(100) notnull pk unique index" json:"name"`
+	Name        string `xorm:"varchar(100) notnull pk" json:"name"`
 	CreatedTime string `xorm:"varchar(100)" json:"createdTime"`
-	UpdatedTime string `xorm:"varchar(100)" json:"updatedTime"`
+	DisplayName string `xorm:"varchar(100)" json:"displayName"`
+	Description string `xorm:"varchar(100)" json:"description"`
 
-	DisplayName  string  `xorm:"varchar(100)" json:"displayName"`
-	Manager      string  `xorm:"varchar(100)" json:"manager"`
-	ContactEmail string  `xorm:"varchar(100)" json:"contactEmail"`
-	Type         string  `xorm:"varchar(100)" json:"type"`
-	ParentId     string  `xorm:"varchar(100)" json:"parentId"`
-	IsTopModel   bool    `xorm:"bool" json:"isTopModel"`
-	Users        []*User `xorm:"-" json:"users"`
-
-	Title    string   `json:"title,omitempty"`
-	Key      string   `json:"key,omitempty"`
-	Children []*Model `json:"children,omitempty"`
-
-	IsEnabled bool `json:"isEnabled"`
+	ModelText string `xorm:"mediumtext" json:"modelText"`
+	IsEnabled bool   `json:"isEnabled"`
 }
 
 func (c *Client) GetModels() ([]*Model, error) {
@@ -69,45 +60,51 @@ func (c *Client) GetPaginationModels(p int, pageSize int, queryMap map[string]st
 
 	url := c.GetUrl("get-models", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var models []*Model
+	total, err := c.doGetPagination(url, &models)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	models, ok := response.Data.([]*Model)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return models, int(response.Data2.(float64)), nil
+	return models, total, nil
 }
 
 func (c *Client) GetModel(name string) (*Model, error) {
-	queryMap := map[string]string{
-		"id": fmt.Sprintf("%s/%s", c.OrganizationName, name),
-	}
-
-	url := c.GetUrl("get-model", queryMap)
+	return c.getModel(context.Background(), fmt.Sprintf("%s/%s", c.OrganizationName, name))
+}
 
-	bytes, err := c.DoGetBytes(url)
-	if err != nil {
-		return nil, err
+// getModel returns the model with the given id ("owner/name"), nil if it does not exist.
+func (c *Client) getModel(ctx context.Context, id string) (*Model, error) {
+	queryMap := map[string]string{
+		"id": id,
 	}
 
 	var model *Model
-	err = json.Unmarshal(bytes, &model)
+	err := c.doGetWithContext(ctx, "get-model", queryMap, &model)
 	if err != nil {
 		return nil, err
 	}
 	return model, nil
 }
 
+// UpdateModel updates the model, whose model text is validated first, see ParseModelText.
 func (c *Client) UpdateModel(model *Model) (bool, error) {
+	err := model.Validate()
+	if err != nil {
+		return false, err
+	}
+
 	_, affected, err := c.modifyModel("update-model", model, nil)
 	return affected, err
 }
 
+// AddModel adds the model, whose model text is validated first, see ParseModelText.
 func (c *Client) AddModel(model *Model) (bool, error) {
+	err := model.Validate()
+	if err != nil {
+		return false, err
+	}
+
 	_, affected, err := c.modifyModel("add-model", model, nil)
 	return affected, err
 }
@@ -116,3 +113,15 @@ func (c *Client) DeleteModel(model *Model) (bool, error) {
 	_, affected, err := c.modifyModel("delete-model", model, nil)
 	return affected, err
 }
+
+// getModelText returns the Casbin model text of the model with the given id ("owner/name").
+func (c *Client) getModelText(ctx context.Context, id string) (string, error) {
+	model, err := c.getModel(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if model == nil {
+		return "", fmt.Errorf("model %s does not exist", id)
+	}
+	return model.ModelText, nil
+}
@@ -3,6 +3,7 @@ package casdoorsdk
 import (
 	_ "embed"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -20,6 +21,7 @@ func TestAddModel(t *testing.T) {
 				Owner:       "casbin-forum",
 				Name:        "test-model1",
 				DisplayName: "Model-Test1",
+				ModelText:   testModelText,
 			},
 			expected:      true,
 			expectedError: nil,
@@ -29,6 +31,7 @@ func TestAddModel(t *testing.T) {
 				Owner:       "casbin-forum",
 				Name:        "test-model2",
 				DisplayName: "Model-Test2",
+				ModelText:   testModelText,
 			},
 			expected:      true,
 			expectedError: nil,
@@ -125,7 +128,7 @@ func TestUpdateModel(t *testing.T) {
 	}{
 		{
 			name:          "test-model1",
-			expected:      &Model{Owner: "casbin-forum", Name: "test-model1", DisplayName: "Model-Test11"},
+			expected:      &Model{Owner: "casbin-forum", Name: "test-model1", DisplayName: "Model-Test11", ModelText: testModelText},
 			expectedError: nil,
 		},
 	}
@@ -181,3 +184,56 @@ func TestDeleteModel(t *testing.T) {
 		}
 	}
 }
+
+func TestParseModelText(t *testing.T) {
+	sections, err := ParseModelText(testModelText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sections.RequestDefinition["r"] != "sub, obj, act" || sections.RoleDefinition["g"] != "_, _" {
+		t.Errorf("Unexpected sections %+v", sections)
+	}
+
+	testCases := []struct {
+		modelText string
+		expected  string
+	}{
+		{strings.Replace(testModelText, "[matchers]", "[matcher]", 1), "line 13: unknown section [matcher]"},
+		{strings.Replace(testModelText, "p = sub, obj, act", "p = sub obj, act", 1), "p has invalid token"},
+		{strings.Replace(testModelText, "p.eft == allow", "p.eft == maybe", 1), "unsupported policy effect"},
+		{strings.Replace(testModelText, "r.act == p.act", "r.act == p.action", 1), "uses p.action, which is not defined"},
+		{strings.Replace(testModelText, "r.act == p.act", "r.act == == p.act", 1), "m is invalid"},
+		{strings.Replace(testModelText, "[policy_effect]", "", 1), "line 11: invalid key e"},
+	}
+	for _, tc := range testCases {
+		_, err = ParseModelText(tc.modelText)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Expected error containing %q, but got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestEvaluateModel(t *testing.T) {
+	model := &Model{Name: "model-rbac", ModelText: testModelText}
+	policies := [][]string{
+		{"p", "admin", "data1", "read"},
+		{"g", "alice", "admin"},
+	}
+	requests := []CasbinRequest{
+		{"alice", "data1", "read"},
+		{"alice", "data1", "write"},
+		{"bob", "data1", "read"},
+	}
+
+	results, err := model.Evaluate(policies, requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || !results[0] || results[1] || results[2] {
+		t.Errorf("Unexpected results %v", results)
+	}
+
+	if _, err = model.Evaluate([][]string{{"p", "admin", "data1"}}, requests); err == nil {
+		t.Errorf("Expected a policy with missing values to be rejected")
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/casbin/govaluate"
+)
+
+var (
+	modelSectionNames = map[string]string{
+		"request_definition": "r",
+		"policy_definition":  "p",
+		"role_definition":    "g",
+		"policy_effect":      "e",
+		"matchers":           "m",
+	}
+
+	// supportedPolicyEffects are the policy effects implemented by Casbin, with "p." escaped to "p_"
+	supportedPolicyEffects = []string{
+		"some(where (p_eft == allow))",
+		"!some(where (p_eft == deny))",
+		"some(where (p_eft == allow)) && !some(where (p_eft == deny))",
+		"priority(p_eft) || deny",
+		"subjectPriority(p_eft) || deny",
+	}
+
+	modelTokenRegex     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	matcherTokenRegex   = regexp.MustCompile(`\b([rp][0-9]*)[._]([A-Za-z0-9_]+)`)
+	matcherLiteralRegex = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	matcherEscapeRegex  = regexp.MustCompile(`\b([rp][0-9]*)\.`)
+)
+
+// ModelSections is a Casbin model text split into its sections, each mapping
+// its definitions to their values, e.g. RequestDefinition["r"] = "sub, obj, act".
+type ModelSections struct {
+	RequestDefinition map[string]string `json:"requestDefinition"`
+	PolicyDefinition  map[string]string `json:"policyDefinition"`
+	RoleDefinition    map[string]string `json:"roleDefinition"`
+	PolicyEffect      map[string]string `json:"policyEffect"`
+	Matchers          map[string]string `json:"matchers"`
+}
+
+// ParseModelText splits a Casbin model text into its sections and validates its syntax:
+// known sections and definitions, tokens, supported policy effects, and matchers that
+// compile and only use defined tokens. Errors report the line they occur at when possible.
+func ParseModelText(modelText string) (*ModelSections, error) {
+	sections := &ModelSections{
+		RequestDefinition: map[string]string{},
+		PolicyDefinition:  map[string]string{},
+		RoleDefinition:    map[string]string{},
+		PolicyEffect:      map[string]string{},
+		Matchers:          map[string]string{},
+	}
+	sectionMap := map[string]map[string]string{
+		"r": sections.RequestDefinition,
+		"p": sections.PolicyDefinition,
+		"g": sections.RoleDefinition,
+		"e": sections.PolicyEffect,
+		"m": sections.Matchers,
+	}
+
+	section := ""
+	lines := strings.Split(strings.ReplaceAll(modelText, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		// a trailing backslash continues the line
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			line = strings.TrimSpace(strings.TrimSuffix(line, "\\")) + " " + strings.TrimSpace(lines[i])
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header %s", lineNumber, line)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			var ok bool
+			section, ok = modelSectionNames[name]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown section [%s]", lineNumber, name)
+			}
+			continue
+		}
+
+		if section == "" {
+			return nil, fmt.Errorf("line %d: definition outside of a section", lineNumber)
+		}
+
+		index := strings.Index(line, "=")
+		if index <= 0 {
+			return nil, fmt.Errorf("line %d: expected \"key = value\", got %s", lineNumber, line)
+		}
+		key := strings.TrimSpace(line[:index])
+		value := strings.TrimSpace(line[index+1:])
+
+		if !strings.HasPrefix(key, section) || strings.Trim(key[len(section):], "0123456789") != "" {
+			return nil, fmt.Errorf("line %d: invalid key %s, expected %s, %s2, ...", lineNumber, key, section, section)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("line %d: %s has no value", lineNumber, key)
+		}
+		if _, ok := sectionMap[section][key]; ok {
+			return nil, fmt.Errorf("line %d: %s is defined twice", lineNumber, key)
+		}
+		sectionMap[section][key] = value
+	}
+
+	err := sections.validate()
+	if err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func (s *ModelSections) validate() error {
+	required := []struct {
+		name        string
+		definitions map[string]string
+	}{
+		{"request_definition", s.RequestDefinition},
+		{"policy_definition", s.PolicyDefinition},
+		{"policy_effect", s.PolicyEffect},
+		{"matchers", s.Matchers},
+	}
+	for _, section := range required {
+		if len(section.definitions) == 0 {
+			return fmt.Errorf("missing section [%s]", section.name)
+		}
+	}
+
+	tokens := map[string][]string{}
+	for _, definitions := range []map[string]string{s.RequestDefinition, s.PolicyDefinition} {
+		for key, value := range definitions {
+			for _, token := range strings.Split(value, ",") {
+				token = strings.TrimSpace(token)
+				if !modelTokenRegex.MatchString(token) {
+					return fmt.Errorf("%s has invalid token %q", key, token)
+				}
+				tokens[key] = append(tokens[key], token)
+			}
+		}
+	}
+
+	for key, value := range s.RoleDefinition {
+		for _, token := range strings.Split(value, ",") {
+			if strings.TrimSpace(token) != "_" {
+				return fmt.Errorf("%s must be made of \"_\", got %s", key, value)
+			}
+		}
+	}
+
+	for key, value := range s.PolicyEffect {
+		effect := strings.ReplaceAll(value, "p.", "p_")
+		if !containsString(supportedPolicyEffects, effect) {
+			return fmt.Errorf("%s has unsupported policy effect %s", key, value)
+		}
+	}
+
+	functionMap := model.LoadFunctionMap()
+	functions := functionMap.GetFunctions()
+	stub := func(arguments ...interface{}) (interface{}, error) { return true, nil }
+	for key := range s.RoleDefinition {
+		functions[key] = stub
+	}
+	functions["eval"] = stub
+
+	for key, value := range s.Matchers {
+		matcher := matcherLiteralRegex.ReplaceAllString(value, "''")
+		for _, match := range matcherTokenRegex.FindAllStringSubmatch(matcher, -1) {
+			definition, token := match[1], match[2]
+			if definition[0] == 'p' && token == "eft" {
+				continue
+			}
+			if !containsString(tokens[definition], token) {
+				return fmt.Errorf("%s uses %s.%s, which is not defined", key, definition, token)
+			}
+		}
+
+		_, err := govaluate.NewEvaluableExpressionWithFunctions(escapeMatcher(value), functions)
+		if err != nil {
+			return fmt.Errorf("%s is invalid: %v", key, err)
+		}
+	}
+
+	// Casbin reports the remaining inconsistencies, such as a matcher without its request definition
+	_, err := model.NewModelFromString(s.String())
+	return err
+}
+
+// escapeMatcher escapes the tokens of a matcher like Casbin does, e.g. "r.sub" to "r_sub".
+func escapeMatcher(matcher string) string {
+	return matcherEscapeRegex.ReplaceAllString(matcher, "${1}_")
+}
+
+// String renders the sections as a model text.
+func (s *ModelSections) String() string {
+	var b strings.Builder
+	sections := []struct {
+		name        string
+		definitions map[string]string
+	}{
+		{"request_definition", s.RequestDefinition},
+		{"policy_definition", s.PolicyDefinition},
+		{"role_definition", s.RoleDefinition},
+		{"policy_effect", s.PolicyEffect},
+		{"matchers", s.Matchers},
+	}
+	for _, section := range sections {
+		if len(section.definitions) == 0 {
+			continue
+		}
+
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[%s]\n", section.name)
+		for _, key := range getSortedKeys(section.definitions) {
+			fmt.Fprintf(&b, "%s = %s\n", key, section.definitions[key])
+		}
+	}
+	return b.String()
+}
+
+// Validate checks the syntax of the model text, see ParseModelText.
+func (m *Model) Validate() error {
+	_, err := ParseModelText(m.ModelText)
+	if err != nil {
+		return fmt.Errorf("model %s: %w", m.Name, err)
+	}
+	return nil
+}
+
+// Evaluate enforces the requests locally against sample policies, without Casdoor, to test a model.
+// Each policy starts with its ptype, e.g. {"p", "alice", "data1", "read"} or {"g", "alice", "admin"}.
+func (m *Model) Evaluate(policies [][]string, requests []CasbinRequest) ([]bool, error) {
+	err := m.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	casbinModel, err := model.NewModelFromString(m.ModelText)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcer, err := casbin.NewEnforcer(casbinModel)
+	if err != nil {
+		return nil, err
+	}
+
+	casbinModel = enforcer.GetModel()
+	for i, policy := range policies {
+		if len(policy) == 0 || policy[0] == "" {
+			return nil, fmt.Errorf("policy %d has no ptype", i)
+		}
+		assertion, ok := casbinModel[policy[0][:1]][policy[0]]
+		if !ok {
+			return nil, fmt.Errorf("policy %d has undefined ptype %s", i, policy[0])
+		}
+		if len(policy)-1 != len(assertion.Tokens) {
+			return nil, fmt.Errorf("policy %d has %d values, %s defines %d", i, len(policy)-1, policy[0], len(assertion.Tokens))
+		}
+
+		err = persist.LoadPolicyArray(policy, casbinModel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = enforcer.BuildRoleLinks()
+	if err != nil {
+		return nil, err
+	}
+	return enforcer.BatchEnforce(requests)
+}
+
+func getSortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
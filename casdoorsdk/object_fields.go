@@ -0,0 +1,241 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// userPropertiesPrefix selects a key of User.Properties in a field name, e.g. "properties.department".
+const userPropertiesPrefix = "properties."
+
+// objectField is a field of a Casdoor object, known by its json name, e.g. "github" for User.GitHub.
+type objectField struct {
+	index  int
+	name   string
+	column string
+	kind   reflect.Kind
+}
+
+var (
+	userFields, userFieldMap = getObjectFields(reflect.TypeOf(User{}))
+	objectFieldsCache        sync.Map
+)
+
+// getObjectFields returns the exported fields of the struct type, named as encoding/json names them,
+// and a map of them by lowercased json name.
+func getObjectFields(t reflect.Type) ([]*objectField, map[string]*objectField) {
+	var fields []*objectField
+	fieldMap := map[string]*objectField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		name := strings.Split(structField.Tag.Get("json"), ",")[0]
+		if name == "-" || structField.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = structField.Name
+		}
+
+		field := &objectField{
+			index:  i,
+			name:   name,
+			column: getXormColumn(structField),
+			kind:   structField.Type.Kind(),
+		}
+		fields = append(fields, field)
+		fieldMap[strings.ToLower(name)] = field
+	}
+	return fields, fieldMap
+}
+
+// getCachedObjectFields returns the fields of getObjectFields, computed once per struct type.
+func getCachedObjectFields(t reflect.Type) []*objectField {
+	fields, ok := objectFieldsCache.Load(t)
+	if !ok {
+		fields, _ = getObjectFields(t)
+		objectFieldsCache.Store(t, fields)
+	}
+	return fields.([]*objectField)
+}
+
+// xormTagKeywords are the xorm tag tokens that are not a column name.
+var xormTagKeywords = map[string]bool{
+	"-": true, "pk": true, "notnull": true, "null": true, "index": true, "unique": true,
+	"created": true, "updated": true, "deleted": true, "version": true, "autoincr": true,
+	"blob": true, "text": true, "mediumtext": true, "longtext": true, "json": true, "bool": true, "int": true, "bigint": true,
+}
+
+// getXormColumn returns the database column of a struct field the way xorm names it in Casdoor:
+// the name given in the xorm tag if any, the snake case of the field name otherwise.
+func getXormColumn(structField reflect.StructField) string {
+	tokens := strings.Fields(structField.Tag.Get("xorm"))
+	if len(tokens) != 0 && !strings.Contains(tokens[0], "(") && !xormTagKeywords[strings.ToLower(tokens[0])] {
+		return strings.Trim(tokens[0], "'")
+	}
+
+	var b strings.Builder
+	for i, r := range structField.Name {
+		if unicode.IsUpper(r) {
+			if i != 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// getUserField returns the field of User with the json name, case-insensitively.
+func getUserField(name string) (*objectField, error) {
+	field, ok := userFieldMap[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown user field %s", name)
+	}
+	return field, nil
+}
+
+// setUserField parses the text value into the user field with the json name, or the properties key.
+// List fields are separated by ";" since the value usually comes from a CSV cell.
+func setUserField(user *User, name string, value string) error {
+	if strings.HasPrefix(strings.ToLower(name), userPropertiesPrefix) {
+		if user.Properties == nil {
+			user.Properties = map[string]string{}
+		}
+		user.Properties[name[len(userPropertiesPrefix):]] = value
+		return nil
+	}
+
+	field, err := getUserField(name)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(user).Elem().Field(field.index)
+	switch field.kind {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		if value == "" {
+			v.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s is not a boolean: %s", field.name, value)
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		if value == "" {
+			v.SetInt(0)
+			return nil
+		}
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s is not an integer: %s", field.name, value)
+		}
+		v.SetInt(int64(i))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%s cannot be set from text", field.name)
+		}
+		var values []string
+		for _, s := range strings.Split(value, ";") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+		v.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("%s cannot be set from text", field.name)
+	}
+	return nil
+}
+
+// getUserFieldValue returns the value of the user field with the json name, or the properties key.
+func getUserFieldValue(user *User, name string) (interface{}, error) {
+	if strings.HasPrefix(strings.ToLower(name), userPropertiesPrefix) {
+		return user.Properties[name[len(userPropertiesPrefix):]], nil
+	}
+
+	field, err := getUserField(name)
+	if err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(user).Elem().Field(field.index).Interface(), nil
+}
+
+// getUserFieldText formats the user field like setUserField parses it, other values are rendered as JSON.
+func getUserFieldText(user *User, name string) (string, error) {
+	value, err := getUserFieldValue(user, name)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case []string:
+		return strings.Join(v, ";"), nil
+	}
+
+	if reflect.ValueOf(value).IsNil() {
+		return "", nil
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ChangedColumns compares two versions of a Casdoor object, e.g. two *User or two *Permission, and returns the
+// database columns of the fields that differ, like "display_name" for User.DisplayName. Empty and nil lists
+// or maps are equal.
+func ChangedColumns(before interface{}, after interface{}) ([]string, error) {
+	beforeValue := reflect.Indirect(reflect.ValueOf(before))
+	afterValue := reflect.Indirect(reflect.ValueOf(after))
+	if !beforeValue.IsValid() || !afterValue.IsValid() {
+		return nil, fmt.Errorf("cannot compare %T with %T, they must not be nil", before, after)
+	}
+	if beforeValue.Kind() != reflect.Struct || beforeValue.Type() != afterValue.Type() {
+		return nil, fmt.Errorf("cannot compare %T with %T, they must be the same object", before, after)
+	}
+
+	var columns []string
+	for _, field := range getCachedObjectFields(beforeValue.Type()) {
+		beforeField := beforeValue.Field(field.index)
+		afterField := afterValue.Field(field.index)
+		if (field.kind == reflect.Slice || field.kind == reflect.Map) && beforeField.Len() == 0 && afterField.Len() == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(beforeField.Interface(), afterField.Interface()) {
+			columns = append(columns, field.column)
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedColumns(t *testing.T) {
+	before := &User{Name: "alice", DisplayName: "Alice", IdCardType: "passport", Groups: nil, Properties: map[string]string{"team": "blue"}}
+
+	testCases := []struct {
+		update   func(user *User)
+		expected []string
+	}{
+		{func(user *User) {}, nil},
+		{func(user *User) { user.Groups = []string{} }, nil},
+		{func(user *User) { user.DisplayName = "Alice A." }, []string{"display_name"}},
+		{func(user *User) { user.IdCardType = "" }, []string{"id_card_type"}},
+		{func(user *User) { user.GitHub = "alice"; user.AzureAD = "alice" }, []string{"github", "azuread"}},
+		{func(user *User) { user.Properties = map[string]string{"team": "red"} }, []string{"properties"}},
+		{func(user *User) { user.ManagedAccounts = []ManagedAccount{{Application: "app"}} }, []string{"managedAccounts"}},
+	}
+	for i, tc := range testCases {
+		after := *before
+		after.Properties = map[string]string{"team": "blue"}
+		tc.update(&after)
+
+		columns, err := ChangedColumns(before, &after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(columns, tc.expected) {
+			t.Errorf("Case %d: expected columns %v, but got %v", i, tc.expected, columns)
+		}
+	}
+
+	columns, err := ChangedColumns(&Permission{Name: "p", Actions: []string{"Read"}}, &Permission{Name: "p", Actions: []string{"Read", "Write"}})
+	if err != nil || !reflect.DeepEqual(columns, []string{"actions"}) {
+		t.Errorf("Expected the actions column, but got %v, %v", columns, err)
+	}
+
+	if _, err = ChangedColumns(before, &Permission{}); err == nil {
+		t.Errorf("Expected different objects to be rejected")
+	}
+
+	var nilUser *User
+	for _, after := range []interface{}{nil, nilUser} {
+		if _, err = ChangedColumns(before, after); err == nil {
+			t.Errorf("Expected %#v to be rejected", after)
+		}
+		if _, err = ChangedColumns(after, before); err == nil {
+			t.Errorf("Expected %#v to be rejected", after)
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package casdoorsdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -67,19 +68,17 @@ type Organization struct {
 }
 
 func (c *Client) GetOrganization(name string) (*Organization, error) {
-	queryMap := map[string]string{
-		"id": fmt.Sprintf("%s/%s", c.OrganizationName, name),
-	}
-
-	url := c.GetUrl("get-organization", queryMap)
+	return c.getOrganization(context.Background(), fmt.Sprintf("%s/%s", c.OrganizationName, name))
+}
 
-	bytes, err := c.DoGetBytes(url)
-	if err != nil {
-		return nil, err
+// getOrganization returns the organization with the id, organizations are owned by "admin", e.g. "admin/built-in".
+func (c *Client) getOrganization(ctx context.Context, id string) (*Organization, error) {
+	queryMap := map[string]string{
+		"id": id,
 	}
 
 	var organization *Organization
-	err = json.Unmarshal(bytes, &organization)
+	err := c.doGetWithContext(ctx, "get-organization", queryMap, &organization)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,200 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	PasswordOptionAtLeast6    = "AtLeast6"
+	PasswordOptionAtLeast8    = "AtLeast8"
+	PasswordOptionAa123       = "Aa123"
+	PasswordOptionSpecialChar = "SpecialChar"
+	PasswordOptionNoRepeat    = "NoRepeat"
+)
+
+// passwordOptionsTTL is how long AddUser and SetPassword reuse the password options of an organization.
+const passwordOptionsTTL = 5 * time.Minute
+
+// passwordSpecialChars are the characters satisfying PasswordOptionSpecialChar.
+const passwordSpecialChars = "!@#$%^&*"
+
+// passwordMessages are the messages of the password options by language, English is the fallback.
+var passwordMessages = map[string]map[string]string{
+	"en": {
+		PasswordOptionAtLeast6:    "The password must have at least 6 characters",
+		PasswordOptionAtLeast8:    "The password must have at least 8 characters",
+		PasswordOptionAa123:       "The password must contain at least one uppercase letter, one lowercase letter and one digit",
+		PasswordOptionSpecialChar: "The password must contain at least one special character",
+		PasswordOptionNoRepeat:    "The password must not contain the same character twice in a row",
+	},
+	"zh": {
+		PasswordOptionAtLeast6:    "密码长度至少为6个字符",
+		PasswordOptionAtLeast8:    "密码长度至少为8个字符",
+		PasswordOptionAa123:       "密码必须包含至少一个大写字母、一个小写字母和一个数字",
+		PasswordOptionSpecialChar: "密码必须包含至少一个特殊字符",
+		PasswordOptionNoRepeat:    "密码不能包含连续重复的字符",
+	},
+	"es": {
+		PasswordOptionAtLeast6:    "La contraseña debe tener al menos 6 caracteres",
+		PasswordOptionAtLeast8:    "La contraseña debe tener al menos 8 caracteres",
+		PasswordOptionAa123:       "La contraseña debe contener al menos una letra mayúscula, una letra minúscula y un dígito",
+		PasswordOptionSpecialChar: "La contraseña debe contener al menos un carácter especial",
+		PasswordOptionNoRepeat:    "La contraseña no debe contener el mismo carácter dos veces seguidas",
+	},
+	"fr": {
+		PasswordOptionAtLeast6:    "Le mot de passe doit comporter au moins 6 caractères",
+		PasswordOptionAtLeast8:    "Le mot de passe doit comporter au moins 8 caractères",
+		PasswordOptionAa123:       "Le mot de passe doit contenir au moins une majuscule, une minuscule et un chiffre",
+		PasswordOptionSpecialChar: "Le mot de passe doit contenir au moins un caractère spécial",
+		PasswordOptionNoRepeat:    "Le mot de passe ne doit pas contenir deux fois de suite le même caractère",
+	},
+	"de": {
+		PasswordOptionAtLeast6:    "Das Passwort muss mindestens 6 Zeichen lang sein",
+		PasswordOptionAtLeast8:    "Das Passwort muss mindestens 8 Zeichen lang sein",
+		PasswordOptionAa123:       "Das Passwort muss mindestens einen Großbuchstaben, einen Kleinbuchstaben und eine Ziffer enthalten",
+		PasswordOptionSpecialChar: "Das Passwort muss mindestens ein Sonderzeichen enthalten",
+		PasswordOptionNoRepeat:    "Das Passwort darf nicht zweimal hintereinander dasselbe Zeichen enthalten",
+	},
+	"ja": {
+		PasswordOptionAtLeast6:    "パスワードは6文字以上である必要があります",
+		PasswordOptionAtLeast8:    "パスワードは8文字以上である必要があります",
+		PasswordOptionAa123:       "パスワードには大文字、小文字、数字をそれぞれ1つ以上含める必要があります",
+		PasswordOptionSpecialChar: "パスワードには特殊文字を1つ以上含める必要があります",
+		PasswordOptionNoRepeat:    "パスワードに連続した同じ文字を含めることはできません",
+	},
+}
+
+// PasswordViolation is a password option of an organization that a password does not satisfy.
+type PasswordViolation struct {
+	// Option is the password option, e.g. PasswordOptionAtLeast8.
+	Option string `json:"option"`
+}
+
+// Message returns the message of the violation in the language, like "en" or "zh-CN", English when it is not supported.
+func (v PasswordViolation) Message(language string) string {
+	language = strings.ToLower(strings.SplitN(strings.Replace(language, "_", "-", 1), "-", 2)[0])
+	messages, ok := passwordMessages[language]
+	if !ok {
+		messages = passwordMessages["en"]
+	}
+	return messages[v.Option]
+}
+
+func (v PasswordViolation) Error() string {
+	return v.Message("en")
+}
+
+// PasswordViolations is the error of AddUser and SetPassword when the password does not satisfy the
+// password options of the organization, use errors.As to get the violations.
+type PasswordViolations []PasswordViolation
+
+func (v PasswordViolations) Error() string {
+	var messages []string
+	for _, violation := range v {
+		messages = append(messages, violation.Error())
+	}
+	return fmt.Sprintf("invalid password: %s", strings.Join(messages, "; "))
+}
+
+// ValidatePassword checks the password against the password options of the organization, like Casdoor does in
+// https://github.com/casdoor/casdoor/blob/master/object/check_password_complexity.go, and returns the options
+// it does not satisfy. Without options, or without organization, the password must have at least 6 characters.
+func ValidatePassword(org *Organization, password string) []PasswordViolation {
+	var passwordOptions []string
+	if org != nil {
+		passwordOptions = org.PasswordOptions
+	}
+	if len(passwordOptions) == 0 {
+		passwordOptions = []string{PasswordOptionAtLeast6}
+	}
+
+	var violations []PasswordViolation
+	for _, option := range passwordOptions {
+		if !checkPasswordOption(option, password) {
+			violations = append(violations, PasswordViolation{Option: option})
+		}
+	}
+	return violations
+}
+
+// checkPasswordOption returns true if the password satisfies the option, unknown options are satisfied.
+// Like Casdoor, lengths count bytes, letters and digits are ASCII ones, and repeats compare consecutive bytes.
+func checkPasswordOption(option string, password string) bool {
+	switch option {
+	case PasswordOptionAtLeast6:
+		return len(password) >= 6
+	case PasswordOptionAtLeast8:
+		return len(password) >= 8
+	case PasswordOptionAa123:
+		return strings.ContainsAny(password, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") &&
+			strings.ContainsAny(password, "abcdefghijklmnopqrstuvwxyz") &&
+			strings.ContainsAny(password, "0123456789")
+	case PasswordOptionSpecialChar:
+		return strings.ContainsAny(password, passwordSpecialChars)
+	case PasswordOptionNoRepeat:
+		for i := 1; i < len(password); i++ {
+			if password[i] == password[i-1] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// cachedPasswordOptions are the password options of an organization fetched by getPasswordOptions.
+type cachedPasswordOptions struct {
+	options     []string
+	fetchedTime time.Time
+}
+
+// getPasswordOptions returns the password options of the organization with the name, cached for passwordOptionsTTL.
+// When the organization cannot be fetched, the last options are returned, and false without any.
+func (c *Client) getPasswordOptions(organizationName string) ([]string, bool) {
+	cached, ok := c.passwordOptions.Load(organizationName)
+	if ok && time.Since(cached.(*cachedPasswordOptions).fetchedTime) < passwordOptionsTTL {
+		return cached.(*cachedPasswordOptions).options, true
+	}
+
+	organization, err := c.getOrganization(context.Background(), fmt.Sprintf("admin/%s", organizationName))
+	if err != nil || organization == nil {
+		if ok {
+			return cached.(*cachedPasswordOptions).options, true
+		}
+		return nil, false
+	}
+
+	c.passwordOptions.Store(organizationName, &cachedPasswordOptions{options: organization.PasswordOptions, fetchedTime: time.Now()})
+	return organization.PasswordOptions, true
+}
+
+// validateUserPassword checks the password against the password options of the organization with the name.
+// When the options are unknown, the password is left to Casdoor, which checks it as well.
+func (c *Client) validateUserPassword(organizationName string, password string) error {
+	passwordOptions, ok := c.getPasswordOptions(organizationName)
+	if !ok {
+		return nil
+	}
+
+	violations := ValidatePassword(&Organization{PasswordOptions: passwordOptions}, password)
+	if len(violations) != 0 {
+		return PasswordViolations(violations)
+	}
+	return nil
+}
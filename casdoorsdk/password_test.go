@@ -0,0 +1,108 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	all := &Organization{PasswordOptions: []string{"AtLeast8", "Aa123", "SpecialChar", "NoRepeat"}}
+
+	testCases := []struct {
+		org      *Organization
+		password string
+		expected []string
+	}{
+		{nil, "12345", []string{"AtLeast6"}},
+		{nil, "123456", nil},
+		{&Organization{}, "12345", []string{"AtLeast6"}},
+		{all, "Abc1!", []string{"AtLeast8"}},
+		{all, "abcdefg1!", []string{"Aa123"}},
+		{all, "Abcdefg12", []string{"SpecialChar"}},
+		{all, "Abcdeefg1!", []string{"NoRepeat"}},
+		{all, "aa", []string{"AtLeast8", "Aa123", "SpecialChar", "NoRepeat"}},
+		{all, "Abcdefg1!", nil},
+		{nil, "密码", nil},
+		{nil, "Ünï", []string{"AtLeast6"}},
+		{all, "Ünïcödé1!", []string{"Aa123"}},
+		{all, "ÜAnïcöödé1!", nil},
+	}
+	for _, tc := range testCases {
+		var options []string
+		for _, violation := range ValidatePassword(tc.org, tc.password) {
+			options = append(options, violation.Option)
+		}
+		if !reflect.DeepEqual(options, tc.expected) {
+			t.Errorf("For password %s, expected violations %v, but got %v", tc.password, tc.expected, options)
+		}
+	}
+
+	violation := PasswordViolation{Option: "AtLeast8"}
+	if violation.Message("zh-CN") != "密码长度至少为8个字符" || violation.Message("xx") != violation.Error() {
+		t.Errorf("Unexpected messages %s, %s", violation.Message("zh-CN"), violation.Message("xx"))
+	}
+}
+
+func TestAddUserValidatesPassword(t *testing.T) {
+	added, fetches := false, 0
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		switch r.URL.Path {
+		case "/api/get-organization":
+			fetches++
+			return &Organization{Owner: "admin", Name: "built-in", PasswordOptions: []string{"AtLeast8", "Aa123"}}, nil
+		case "/api/add-user":
+			added = true
+			return "Affected", nil
+		}
+		return nil, nil
+	})
+
+	_, err := client.AddUser(&User{Name: "alice", Password: "secret"})
+	var violations PasswordViolations
+	if !errors.As(err, &violations) || len(violations) != 2 || added {
+		t.Errorf("Expected the password to be rejected before sending, got %v", err)
+	}
+
+	ok, err := client.AddUser(&User{Name: "alice", Password: "Secret123"})
+	if err != nil || !ok || !added {
+		t.Errorf("Expected the user to be added, got %v, %v", ok, err)
+	}
+	if fetches != 1 {
+		t.Errorf("Expected the organization to be fetched once, but got %d", fetches)
+	}
+}
+
+func TestAddUserWithoutOrganization(t *testing.T) {
+	added := false
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		switch r.URL.Path {
+		case "/api/get-organization":
+			return nil, errors.New("the organization is not accessible")
+		case "/api/add-user":
+			added = true
+			return "Affected", nil
+		}
+		return nil, nil
+	})
+
+	ok, err := client.AddUser(&User{Name: "alice", Password: "secret"})
+	if err != nil || !ok || !added {
+		t.Errorf("Expected the password to be left to Casdoor, got %v, %v", ok, err)
+	}
+}
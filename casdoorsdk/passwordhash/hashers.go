@@ -0,0 +1,244 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passwordhash
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// pbkdf2SaltIterations and pbkdf2SaltKeyLength are the ones of Keycloak, whose users this type imports.
+	pbkdf2SaltIterations   = 27500
+	pbkdf2SaltKeyLength    = 64
+	pbkdf2DjangoAlgorithm  = "pbkdf2_sha256"
+	pbkdf2DjangoIterations = 260000
+	pbkdf2DjangoSaltChars  = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// pbkdf2DjangoMaxIterations bounds the iterations read from a hash, so that a corrupted or hostile
+	// hash cannot make Verify run for minutes.
+	pbkdf2DjangoMaxIterations = 10000000
+
+	argon2idMemory      = 64 * 1024
+	argon2idIterations  = 1
+	argon2idParallelism = 2
+	argon2idSaltLength  = 16
+	argon2idKeyLength   = 32
+	// argon2idMaxMemory (in KiB) and argon2idMaxIterations bound the parameters read from a hash,
+	// so that a corrupted or hostile hash cannot exhaust the memory or the CPU in Verify.
+	argon2idMaxMemory     = 1024 * 1024
+	argon2idMaxIterations = 100
+)
+
+func equal(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func getSha256HexDigest(s string) string {
+	hash := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(hash[:])
+}
+
+func getMd5HexDigest(s string) string {
+	hash := md5.Sum([]byte(s))
+	return hex.EncodeToString(hash[:])
+}
+
+type plainHasher struct{}
+
+func (plainHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	return password, nil
+}
+
+func (plainHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	return equal(hash, password), nil
+}
+
+// sha256SaltHasher hashes with SHA-256, then with the organization salt appended to the hex digest.
+type sha256SaltHasher struct{}
+
+func (sha256SaltHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	res := getSha256HexDigest(password)
+	if organizationSalt != "" {
+		res = getSha256HexDigest(res + organizationSalt)
+	}
+	return res, nil
+}
+
+func (h sha256SaltHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	expected, err := h.Hash(password, userSalt, organizationSalt)
+	return equal(hash, expected), err
+}
+
+// md5SaltHasher hashes with MD5, then with the user salt appended to the hex digest.
+type md5SaltHasher struct{}
+
+func (md5SaltHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	res := getMd5HexDigest(password)
+	if userSalt != "" {
+		res = getMd5HexDigest(res + userSalt)
+	}
+	return res, nil
+}
+
+func (h md5SaltHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	expected, err := h.Hash(password, userSalt, organizationSalt)
+	return equal(hash, expected), err
+}
+
+// bcryptHasher keeps its own salt in the hash.
+type bcryptHasher struct{}
+
+func (bcryptHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (bcryptHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// pbkdf2SaltHasher derives a key with PBKDF2-SHA256 from the user salt, which is base64 encoded like the key.
+type pbkdf2SaltHasher struct{}
+
+func (pbkdf2SaltHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	salt, err := base64.StdEncoding.DecodeString(userSalt)
+	if err != nil {
+		return "", fmt.Errorf("the user salt of %s must be base64 encoded: %w", TypePbkdf2Salt, err)
+	}
+
+	key := pbkdf2.Key([]byte(password), salt, pbkdf2SaltIterations, pbkdf2SaltKeyLength, sha256.New)
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (h pbkdf2SaltHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	expected, err := h.Hash(password, userSalt, organizationSalt)
+	if err != nil {
+		return false, err
+	}
+	return equal(hash, expected), nil
+}
+
+// argon2idHasher uses the PHC string format, e.g. "$argon2id$v=19$m=65536,t=1,p=2$<salt>$<key>",
+// which keeps the parameters and the generated salt.
+type argon2idHasher struct{}
+
+func (argon2idHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argon2idIterations, argon2idMemory, argon2idParallelism, argon2idKeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, argon2idMemory, argon2idIterations, argon2idParallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (argon2idHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2id version %s", parts[2])
+	}
+
+	var memory, iterations uint32
+	var parallelism uint8
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism)
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2id parameters %s", parts[3])
+	}
+	if iterations < 1 || iterations > argon2idMaxIterations || parallelism < 1 || memory > argon2idMaxMemory {
+		return false, fmt.Errorf("unsupported argon2id parameters %s", parts[3])
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2id key: %w", err)
+	}
+	if len(key) == 0 {
+		return false, errors.New("invalid argon2id key: empty")
+	}
+
+	expected := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
+
+// pbkdf2DjangoHasher uses the format of Django, "pbkdf2_sha256$<iterations>$<salt>$<key>", with the user salt,
+// the organization salt, or a random salt when both are empty.
+type pbkdf2DjangoHasher struct{}
+
+func (pbkdf2DjangoHasher) Hash(password string, userSalt string, organizationSalt string) (string, error) {
+	salt := userSalt
+	if salt == "" {
+		salt = organizationSalt
+	}
+	if salt == "" {
+		b := make([]byte, 22)
+		_, err := rand.Read(b)
+		if err != nil {
+			return "", err
+		}
+		for i := range b {
+			b[i] = pbkdf2DjangoSaltChars[int(b[i])%len(pbkdf2DjangoSaltChars)]
+		}
+		salt = string(b)
+	}
+
+	key := pbkdf2.Key([]byte(password), []byte(salt), pbkdf2DjangoIterations, sha256.Size, sha256.New)
+	return fmt.Sprintf("%s$%d$%s$%s", pbkdf2DjangoAlgorithm, pbkdf2DjangoIterations, salt, base64.StdEncoding.EncodeToString(key)), nil
+}
+
+func (pbkdf2DjangoHasher) Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	parts := strings.SplitN(hash, "$", 4)
+	if len(parts) != 4 || parts[0] != pbkdf2DjangoAlgorithm {
+		return false, errors.New("invalid pbkdf2-django hash")
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 || iterations > pbkdf2DjangoMaxIterations {
+		return false, fmt.Errorf("invalid pbkdf2-django iterations %s", parts[1])
+	}
+
+	key := pbkdf2.Key([]byte(password), []byte(parts[2]), iterations, sha256.Size, sha256.New)
+	return equal(parts[3], base64.StdEncoding.EncodeToString(key)), nil
+}
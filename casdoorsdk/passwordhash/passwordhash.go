@@ -0,0 +1,140 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package passwordhash produces and verifies password hashes like Casdoor does for each password type,
+// see https://github.com/casdoor/casdoor/tree/master/cred. It lets a migration pre-hash imported passwords,
+// and verify passwords offline against hashes exported from Casdoor.
+package passwordhash
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+const (
+	TypePlain        = "plain"
+	TypeSalt         = "salt"
+	TypeSha256Salt   = "sha256-salt"
+	TypeMd5Salt      = "md5-salt"
+	TypeBcrypt       = "bcrypt"
+	TypePbkdf2Salt   = "pbkdf2-salt"
+	TypeArgon2id     = "argon2id"
+	TypePbkdf2Django = "pbkdf2-django"
+)
+
+// userSaltLength is the length in bytes of the user salts generated by HashUser, before base64 encoding.
+const userSaltLength = 16
+
+// userSaltTypes are the password types hashing with the user salt, for which HashUser generates one.
+var userSaltTypes = map[string]bool{
+	TypeMd5Salt:    true,
+	TypePbkdf2Salt: true,
+}
+
+// Hasher hashes passwords for a password type. Each type uses the user salt, the organization salt, or none,
+// like Casdoor: bcrypt and argon2id generate their own salt and keep it in the hash.
+type Hasher interface {
+	Hash(password string, userSalt string, organizationSalt string) (string, error)
+	Verify(hash string, password string, userSalt string, organizationSalt string) (bool, error)
+}
+
+var hashers = map[string]Hasher{
+	TypePlain:        plainHasher{},
+	TypeSalt:         sha256SaltHasher{},
+	TypeSha256Salt:   sha256SaltHasher{},
+	TypeMd5Salt:      md5SaltHasher{},
+	TypeBcrypt:       bcryptHasher{},
+	TypePbkdf2Salt:   pbkdf2SaltHasher{},
+	TypeArgon2id:     argon2idHasher{},
+	TypePbkdf2Django: pbkdf2DjangoHasher{},
+}
+
+// GetHasher returns the hasher of the password type, an empty type is TypePlain.
+func GetHasher(passwordType string) (Hasher, error) {
+	if passwordType == "" {
+		passwordType = TypePlain
+	}
+
+	hasher, ok := hashers[passwordType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported password type %s", passwordType)
+	}
+	return hasher, nil
+}
+
+func Hash(passwordType string, password string, userSalt string, organizationSalt string) (string, error) {
+	hasher, err := GetHasher(passwordType)
+	if err != nil {
+		return "", err
+	}
+	return hasher.Hash(password, userSalt, organizationSalt)
+}
+
+func Verify(passwordType string, hash string, password string, userSalt string, organizationSalt string) (bool, error) {
+	hasher, err := GetHasher(passwordType)
+	if err != nil {
+		return false, err
+	}
+	return hasher.Verify(hash, password, userSalt, organizationSalt)
+}
+
+// HashUser sets the password of the user hashed with the password type of the organization, and sets its
+// PasswordType accordingly, so that the user can be imported with AddUser. When the type hashes with the user
+// salt and User.PasswordSalt is empty, a random base64 encoded salt is generated and set on the user.
+func HashUser(user *casdoorsdk.User, org *casdoorsdk.Organization, password string) error {
+	if user == nil || org == nil {
+		return errors.New("the user and the organization must not be nil")
+	}
+
+	salt := user.PasswordSalt
+	if salt == "" && userSaltTypes[org.PasswordType] {
+		b := make([]byte, userSaltLength)
+		_, err := rand.Read(b)
+		if err != nil {
+			return err
+		}
+		salt = base64.StdEncoding.EncodeToString(b)
+	}
+
+	hash, err := Hash(org.PasswordType, password, salt, org.PasswordSalt)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hash
+	user.PasswordSalt = salt
+	user.PasswordType = org.PasswordType
+	if user.PasswordType == "" {
+		user.PasswordType = TypePlain
+	}
+	return nil
+}
+
+// VerifyUser checks the password against the hashed password of the user, with the password type of the user,
+// or the one of the organization when the user has none.
+func VerifyUser(user *casdoorsdk.User, org *casdoorsdk.Organization, password string) (bool, error) {
+	if user == nil || org == nil {
+		return false, errors.New("the user and the organization must not be nil")
+	}
+
+	passwordType := user.PasswordType
+	if passwordType == "" {
+		passwordType = org.PasswordType
+	}
+	return Verify(passwordType, user.Password, password, user.PasswordSalt, org.PasswordSalt)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passwordhash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+func TestHash(t *testing.T) {
+	// the expected hashes are computed independently, e.g. with Python's hashlib
+	testCases := []struct {
+		passwordType string
+		userSalt     string
+		expected     string
+	}{
+		{TypePlain, "", "123456"},
+		{TypeSalt, "", "4cf09746dc3b95adf1d059cb195a90d7a006cf0e5b0ab4bbd95da8517288aee5"},
+		{TypeSha256Salt, "", "4cf09746dc3b95adf1d059cb195a90d7a006cf0e5b0ab4bbd95da8517288aee5"},
+		{TypeMd5Salt, "user-salt", "7e52cb8a6413bb75bb77acc880499641"},
+		{TypePbkdf2Salt, "c2VhLXNhbHQ=", "yLTLg9pXhYrBMx4qHGn9B45MhQ1JhkiGy8PzLZAxvFk/R8Xwn7Ruw7ci+HkHdUt/dP/T+bUu24JbGwC0cZ0D1A=="},
+		{TypePbkdf2Django, "seasalt", "pbkdf2_sha256$260000$seasalt$E2mrUojHaz8SdnE/t+CtXDtYhIwAXyKdGbJRZvG53G4="},
+	}
+	for _, tc := range testCases {
+		hash, err := Hash(tc.passwordType, "123456", tc.userSalt, "org-salt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != tc.expected {
+			t.Errorf("For %s, expected hash %s, but got %s", tc.passwordType, tc.expected, hash)
+		}
+	}
+
+	if _, err := Hash("sha1", "123456", "", ""); err == nil {
+		t.Errorf("Expected an unsupported password type to be rejected")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	for passwordType := range hashers {
+		hash, err := Hash(passwordType, "123456", "c2VhLXNhbHQ=", "org-salt")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ok, err := Verify(passwordType, hash, "123456", "c2VhLXNhbHQ=", "org-salt")
+		if err != nil || !ok {
+			t.Errorf("For %s, expected the password to match, got %v, %v", passwordType, ok, err)
+		}
+		ok, err = Verify(passwordType, hash, "1234567", "c2VhLXNhbHQ=", "org-salt")
+		if err != nil || ok {
+			t.Errorf("For %s, expected another password not to match, got %v, %v", passwordType, ok, err)
+		}
+	}
+
+	hash, _ := Hash(TypeArgon2id, "123456", "", "")
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=1,p=2$") {
+		t.Errorf("Unexpected argon2id hash %s", hash)
+	}
+	if _, err := Verify(TypeArgon2id, "$argon2id$v=19$invalid", "123456", "", ""); err == nil {
+		t.Errorf("Expected an invalid argon2id hash to be rejected")
+	}
+
+	invalidHashes := map[string]string{
+		TypeArgon2id + " t=0":      "$argon2id$v=19$m=65536,t=0,p=2$c2VhLXNhbHQ$a2V5",
+		TypeArgon2id + " p=0":      "$argon2id$v=19$m=65536,t=1,p=0$c2VhLXNhbHQ$a2V5",
+		TypeArgon2id + " m":        "$argon2id$v=19$m=4294967295,t=1,p=2$c2VhLXNhbHQ$a2V5",
+		TypeArgon2id + " t":        "$argon2id$v=19$m=65536,t=4294967295,p=2$c2VhLXNhbHQ$a2V5",
+		TypeArgon2id + " key":      "$argon2id$v=19$m=65536,t=1,p=2$c2VhLXNhbHQ$",
+		TypePbkdf2Django + " iter": "pbkdf2_sha256$2000000000$salt$a2V5",
+	}
+	for name, hash := range invalidHashes {
+		passwordType := strings.SplitN(name, " ", 2)[0]
+		if _, err := Verify(passwordType, hash, "123456", "", ""); err == nil {
+			t.Errorf("Expected the %s hash %s to be rejected", name, hash)
+		}
+	}
+}
+
+func TestHashUser(t *testing.T) {
+	org := &casdoorsdk.Organization{Name: "built-in", PasswordType: TypeMd5Salt}
+	user := &casdoorsdk.User{Name: "alice", PasswordSalt: "user-salt"}
+
+	err := HashUser(user, org, "123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Password != "7e52cb8a6413bb75bb77acc880499641" || user.PasswordType != TypeMd5Salt {
+		t.Errorf("Unexpected hashed user %s, %s", user.Password, user.PasswordType)
+	}
+
+	// the user keeps its password type when the organization changes its own
+	org.PasswordType = TypeBcrypt
+	ok, err := VerifyUser(user, org, "123456")
+	if err != nil || !ok {
+		t.Errorf("Expected the password to match, got %v, %v", ok, err)
+	}
+
+	for _, passwordType := range []string{TypeMd5Salt, TypePbkdf2Salt} {
+		org.PasswordType = passwordType
+		first, second := &casdoorsdk.User{Name: "alice"}, &casdoorsdk.User{Name: "bob"}
+		if HashUser(first, org, "123456") != nil || HashUser(second, org, "123456") != nil {
+			t.Fatalf("Expected the users to be hashed with %s", passwordType)
+		}
+		if first.PasswordSalt == "" || first.PasswordSalt == second.PasswordSalt || first.Password == second.Password {
+			t.Errorf("Expected random salts for %s, got %s and %s", passwordType, first.PasswordSalt, second.PasswordSalt)
+		}
+		ok, err = VerifyUser(first, org, "123456")
+		if err != nil || !ok {
+			t.Errorf("Expected the password to match with %s, got %v, %v", passwordType, ok, err)
+		}
+	}
+
+	if HashUser(user, nil, "123456") == nil {
+		t.Errorf("Expected a nil organization to be rejected")
+	}
+	if _, err = VerifyUser(user, nil, "123456"); err == nil {
+		t.Errorf("Expected a nil organization to be rejected")
+	}
+}
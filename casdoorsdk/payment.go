@@ -84,17 +84,13 @@ func (c *Client) GetPaginationPayments(p int, pageSize int, queryMap map[string]
 
 	url := c.GetUrl("get-payments", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var payments []*Payment
+	total, err := c.doGetPagination(url, &payments)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	payments, ok := response.Data.([]*Payment)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return payments, int(response.Data2.(float64)), nil
+	return payments, total, nil
 }
 
 func (c *Client) GetPayment(name string) (*Payment, error) {
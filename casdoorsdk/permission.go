@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -29,6 +28,7 @@ type Permission struct {
 	Description string `xorm:"varchar(100)" json:"description"`
 
 	Users   []string `xorm:"mediumtext" json:"users"`
+	Groups  []string `xorm:"mediumtext" json:"groups"`
 	Roles   []string `xorm:"mediumtext" json:"roles"`
 	Domains []string `xorm:"mediumtext" json:"domains"`
 
@@ -86,6 +86,41 @@ func (c *Client) GetPermissionsByRole(name string) ([]*Permission, error) {
 	return permissions, nil
 }
 
+// GetAllObjects returns the objects the user (owner/name) is granted by the permissions of Casdoor.
+func (c *Client) GetAllObjects(userId string) ([]string, error) {
+	return c.getAllStrings("get-all-objects", userId)
+}
+
+// GetAllActions returns the actions the user (owner/name) is granted by the permissions of Casdoor.
+func (c *Client) GetAllActions(userId string) ([]string, error) {
+	return c.getAllStrings("get-all-actions", userId)
+}
+
+// GetAllRoles returns the roles of the user (owner/name), including the inherited ones.
+func (c *Client) GetAllRoles(userId string) ([]string, error) {
+	return c.getAllStrings("get-all-roles", userId)
+}
+
+func (c *Client) getAllStrings(action string, userId string) ([]string, error) {
+	queryMap := map[string]string{
+		"userId": userId,
+	}
+
+	url := c.GetUrl(action, queryMap)
+
+	bytes, err := c.DoGetBytes(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	err = json.Unmarshal(bytes, &values)
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 func (c *Client) GetPaginationPermissions(p int, pageSize int, queryMap map[string]string) ([]*Permission, int, error) {
 	queryMap["owner"] = c.OrganizationName
 	queryMap["p"] = strconv.Itoa(p)
@@ -93,17 +128,13 @@ func (c *Client) GetPaginationPermissions(p int, pageSize int, queryMap map[stri
 
 	url := c.GetUrl("get-permissions", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var permissions []*Permission
+	total, err := c.doGetPagination(url, &permissions)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	permissions, ok := response.Data.([]*Permission)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return permissions, int(response.Data2.(float64)), nil
+	return permissions, total, nil
 }
 
 func (c *Client) GetPermission(name string) (*Permission, error) {
@@ -22,6 +22,18 @@ func GetPermissionsByRole(name string) ([]*Permission, error) {
 	return globalClient.GetPermissionsByRole(name)
 }
 
+func GetAllObjects(userId string) ([]string, error) {
+	return globalClient.GetAllObjects(userId)
+}
+
+func GetAllActions(userId string) ([]string, error) {
+	return globalClient.GetAllActions(userId)
+}
+
+func GetAllRoles(userId string) ([]string, error) {
+	return globalClient.GetAllRoles(userId)
+}
+
 func GetPaginationPermissions(p int, pageSize int, queryMap map[string]string) ([]*Permission, int, error) {
 	return globalClient.GetPaginationPermissions(p, pageSize, queryMap)
 }
@@ -185,3 +185,40 @@ func TestDeletePermission(t *testing.T) {
 		}
 	}
 }
+
+func TestGetEffectivePermissions(t *testing.T) {
+	user := &User{Owner: "built-in", Name: "alice", Groups: []string{"built-in/dev"}}
+	groups := []*Group{
+		{Owner: "built-in", Name: "engineering", ParentId: "built-in", IsTopGroup: true},
+		{Owner: "built-in", Name: "dev", ParentId: "engineering"},
+	}
+	roles := []*Role{
+		{Owner: "built-in", Name: "reader", Groups: []string{"built-in/engineering"}, IsEnabled: true},
+		{Owner: "built-in", Name: "admin", Roles: []string{"built-in/reader"}, Domains: []string{"domain1"}, IsEnabled: true},
+		{Owner: "built-in", Name: "retired", Users: []string{"built-in/alice"}},
+	}
+	permissions := []*Permission{
+		{Owner: "built-in", Name: "direct", Users: []string{"built-in/alice"}, Resources: []string{"data1"}, Actions: []string{"Read"}, Effect: "Allow", IsEnabled: true},
+		{Owner: "built-in", Name: "inherited", Roles: []string{"built-in/admin"}, Domains: []string{"domain1", "domain2"}, Resources: []string{"data2"}, Actions: []string{"Write"}, Effect: "Allow", IsEnabled: true},
+		{Owner: "built-in", Name: "denied", Groups: []string{"built-in/dev"}, Resources: []string{"data3"}, Actions: []string{"Read"}, Effect: "Deny", IsEnabled: true},
+		{Owner: "built-in", Name: "disabled", Users: []string{"built-in/alice"}, Resources: []string{"data4"}, Actions: []string{"Read"}, Effect: "Allow"},
+		{Owner: "built-in", Name: "other", Users: []string{"built-in/bob"}, Resources: []string{"data5"}, Actions: []string{"Read"}, Effect: "Allow", IsEnabled: true},
+		{Owner: "built-in", Name: "retired-role", Roles: []string{"built-in/retired"}, Resources: []string{"data6"}, Actions: []string{"Read"}, Effect: "Allow", IsEnabled: true},
+	}
+
+	expected := []EffectivePermission{
+		{Resource: "data1", Action: "Read", Effect: "Allow", PermissionId: "built-in/direct", Via: "built-in/alice"},
+		{Resource: "data2", Action: "Write", Effect: "Allow", Domain: "domain1", PermissionId: "built-in/inherited", Via: "built-in/admin"},
+		{Resource: "data3", Action: "Read", Effect: "Deny", PermissionId: "built-in/denied", Via: "built-in/dev"},
+	}
+
+	effectivePermissions := getEffectivePermissions(user, permissions, roles, groups)
+	if len(effectivePermissions) != len(expected) {
+		t.Fatalf("Expected %d effective permissions, got %d", len(expected), len(effectivePermissions))
+	}
+	for i, effectivePermission := range effectivePermissions {
+		if *effectivePermission != expected[i] {
+			t.Errorf("Expected %+v, but got %+v", expected[i], *effectivePermission)
+		}
+	}
+}
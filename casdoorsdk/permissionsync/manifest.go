@@ -0,0 +1,158 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package permissionsync keeps the roles and permissions of a Casdoor organization in sync
+// with a manifest reviewed in git, like:
+//
+//	roles:
+//	  - name: admin
+//	    users: [built-in/alice]
+//	permissions:
+//	  - name: permission-data
+//	    roles: [built-in/admin]
+//	    resources: [data1]
+//	    actions: [Read, Write]
+//	    effect: Allow
+//	    model: model-rbac
+//
+// The fields have the JSON names of casdoorsdk.Role and casdoorsdk.Permission, and isEnabled defaults to true.
+// A text field left out or empty keeps the value of the existing object, while lists are always synced.
+package permissionsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest is the desired state of the roles and permissions of an organization.
+type Manifest struct {
+	Roles       []*casdoorsdk.Role
+	Permissions []*casdoorsdk.Permission
+}
+
+// LoadManifest reads a YAML or JSON manifest file.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// ParseManifest parses a YAML or JSON manifest, JSON being a subset of YAML.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var document interface{}
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, err
+	}
+
+	// the objects are decoded with their JSON tags, so the YAML document is converted to JSON first
+	jsonData, err := json.Marshal(toJsonValue(document))
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Roles       []json.RawMessage `json:"roles"`
+		Permissions []json.RawMessage `json:"permissions"`
+	}
+	err = json.Unmarshal(jsonData, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	for i, data := range raw.Roles {
+		role := &casdoorsdk.Role{IsEnabled: true}
+		err = json.Unmarshal(data, role)
+		if err != nil {
+			return nil, fmt.Errorf("role %d: %w", i, err)
+		}
+		manifest.Roles = append(manifest.Roles, role)
+	}
+	for i, data := range raw.Permissions {
+		permission := &casdoorsdk.Permission{IsEnabled: true}
+		err = json.Unmarshal(data, permission)
+		if err != nil {
+			return nil, fmt.Errorf("permission %d: %w", i, err)
+		}
+		manifest.Permissions = append(manifest.Permissions, permission)
+	}
+
+	err = manifest.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Validate checks that every object has a unique name and that the effects are Allow or Deny.
+func (m *Manifest) Validate() error {
+	roleNames := map[string]bool{}
+	for i, role := range m.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("role %d has no name", i)
+		}
+		if roleNames[role.Name] {
+			return fmt.Errorf("role %s is declared twice", role.Name)
+		}
+		roleNames[role.Name] = true
+	}
+
+	permissionNames := map[string]bool{}
+	for i, permission := range m.Permissions {
+		if permission.Name == "" {
+			return fmt.Errorf("permission %d has no name", i)
+		}
+		if permissionNames[permission.Name] {
+			return fmt.Errorf("permission %s is declared twice", permission.Name)
+		}
+		permissionNames[permission.Name] = true
+
+		if permission.Effect != "" && permission.Effect != "Allow" && permission.Effect != "Deny" {
+			return fmt.Errorf("permission %s has invalid effect %s, it must be Allow or Deny", permission.Name, permission.Effect)
+		}
+	}
+	return nil
+}
+
+// toJsonValue converts the maps decoded by yaml.v2, whose keys are interface{}, to maps with string keys.
+func toJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		res := map[string]interface{}{}
+		for key, value := range v {
+			res[fmt.Sprintf("%v", key)] = toJsonValue(value)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, value := range v {
+			res[i] = toJsonValue(value)
+		}
+		return res
+	default:
+		return v
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permissionsync
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+
+	KindRole       = "role"
+	KindPermission = "permission"
+)
+
+type Options struct {
+	// Prune deletes the roles and permissions of the organization that are not in the manifest.
+	Prune bool
+	// DryRun only computes the plan, without changing anything in Casdoor.
+	DryRun bool
+}
+
+// Change is a create, update or delete of a role or a permission.
+type Change struct {
+	Action string
+	Kind   string
+	Name   string
+	// Fields are the JSON names of the fields changed by an update.
+	Fields []string
+	// Role or Permission is the object sent to Casdoor, depending on Kind.
+	Role       *casdoorsdk.Role
+	Permission *casdoorsdk.Permission
+	Applied    bool
+}
+
+// Plan is the list of changes bringing the organization to the state of a manifest, in the order they are applied:
+// roles before the permissions referencing them, and deletes last.
+type Plan struct {
+	Changes []*Change
+}
+
+// Sync computes the plan bringing the organization of the client to the state of the manifest,
+// and applies it unless options.DryRun is set.
+func Sync(client *casdoorsdk.Client, manifest *Manifest, options Options) (*Plan, error) {
+	plan, err := Diff(client, manifest, options.Prune)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DryRun {
+		return plan, nil
+	}
+	return plan, plan.Apply(client)
+}
+
+// Diff compares the manifest with the roles and permissions of the organization of the client.
+// Without prune, the objects missing from the manifest are kept.
+func Diff(client *casdoorsdk.Client, manifest *Manifest, prune bool) (*Plan, error) {
+	roles, err := client.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := client.GetPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	return diff(client.OrganizationName, manifest, roles, permissions, prune), nil
+}
+
+func diff(owner string, manifest *Manifest, roles []*casdoorsdk.Role, permissions []*casdoorsdk.Permission, prune bool) *Plan {
+	plan := &Plan{}
+
+	roleMap := map[string]*casdoorsdk.Role{}
+	for _, role := range roles {
+		roleMap[role.Name] = role
+	}
+	desiredRoles := map[string]bool{}
+	for _, desired := range manifest.Roles {
+		desiredRoles[desired.Name] = true
+
+		existing, ok := roleMap[desired.Name]
+		if !ok {
+			role := *desired
+			role.Owner = owner
+			plan.Changes = append(plan.Changes, &Change{Action: ActionCreate, Kind: KindRole, Name: desired.Name, Role: &role})
+			continue
+		}
+
+		role := *existing
+		fields := updateRole(&role, desired)
+		if len(fields) != 0 {
+			plan.Changes = append(plan.Changes, &Change{Action: ActionUpdate, Kind: KindRole, Name: desired.Name, Fields: fields, Role: &role})
+		}
+	}
+
+	permissionMap := map[string]*casdoorsdk.Permission{}
+	for _, permission := range permissions {
+		permissionMap[permission.Name] = permission
+	}
+	desiredPermissions := map[string]bool{}
+	for _, desired := range manifest.Permissions {
+		desiredPermissions[desired.Name] = true
+
+		existing, ok := permissionMap[desired.Name]
+		if !ok {
+			permission := *desired
+			permission.Owner = owner
+			plan.Changes = append(plan.Changes, &Change{Action: ActionCreate, Kind: KindPermission, Name: desired.Name, Permission: &permission})
+			continue
+		}
+
+		permission := *existing
+		fields := updatePermission(&permission, desired)
+		if len(fields) != 0 {
+			plan.Changes = append(plan.Changes, &Change{Action: ActionUpdate, Kind: KindPermission, Name: desired.Name, Fields: fields, Permission: &permission})
+		}
+	}
+
+	if prune {
+		for _, permission := range permissions {
+			if !desiredPermissions[permission.Name] {
+				plan.Changes = append(plan.Changes, &Change{Action: ActionDelete, Kind: KindPermission, Name: permission.Name, Permission: permission})
+			}
+		}
+		for _, role := range roles {
+			if !desiredRoles[role.Name] {
+				plan.Changes = append(plan.Changes, &Change{Action: ActionDelete, Kind: KindRole, Name: role.Name, Role: role})
+			}
+		}
+	}
+	return plan
+}
+
+// updateRole copies the fields managed by the manifest from desired to role, and returns the changed ones.
+func updateRole(role *casdoorsdk.Role, desired *casdoorsdk.Role) []string {
+	var fields []string
+	updateString(&fields, "displayName", &role.DisplayName, desired.DisplayName)
+	updateString(&fields, "description", &role.Description, desired.Description)
+	updateStrings(&fields, "users", &role.Users, desired.Users)
+	updateStrings(&fields, "groups", &role.Groups, desired.Groups)
+	updateStrings(&fields, "roles", &role.Roles, desired.Roles)
+	updateStrings(&fields, "domains", &role.Domains, desired.Domains)
+	updateBool(&fields, "isEnabled", &role.IsEnabled, desired.IsEnabled)
+	return fields
+}
+
+// updatePermission copies the fields managed by the manifest from desired to permission, and returns the changed ones.
+func updatePermission(permission *casdoorsdk.Permission, desired *casdoorsdk.Permission) []string {
+	var fields []string
+	updateString(&fields, "displayName", &permission.DisplayName, desired.DisplayName)
+	updateString(&fields, "description", &permission.Description, desired.Description)
+	updateStrings(&fields, "users", &permission.Users, desired.Users)
+	updateStrings(&fields, "groups", &permission.Groups, desired.Groups)
+	updateStrings(&fields, "roles", &permission.Roles, desired.Roles)
+	updateStrings(&fields, "domains", &permission.Domains, desired.Domains)
+	updateString(&fields, "model", &permission.Model, desired.Model)
+	updateString(&fields, "adapter", &permission.Adapter, desired.Adapter)
+	updateString(&fields, "resourceType", &permission.ResourceType, desired.ResourceType)
+	updateStrings(&fields, "resources", &permission.Resources, desired.Resources)
+	updateStrings(&fields, "actions", &permission.Actions, desired.Actions)
+	updateString(&fields, "effect", &permission.Effect, desired.Effect)
+	updateBool(&fields, "isEnabled", &permission.IsEnabled, desired.IsEnabled)
+	return fields
+}
+
+// updateString treats an empty desired value as unspecified, so that a field left out of the manifest
+// keeps the value set in Casdoor, e.g. the effect or the model of a permission.
+func updateString(fields *[]string, name string, value *string, desired string) {
+	if desired != "" && *value != desired {
+		*value = desired
+		*fields = append(*fields, name)
+	}
+}
+
+func updateBool(fields *[]string, name string, value *bool, desired bool) {
+	if *value != desired {
+		*value = desired
+		*fields = append(*fields, name)
+	}
+}
+
+// updateStrings compares the lists as sets, since their order is not meaningful.
+func updateStrings(fields *[]string, name string, value *[]string, desired []string) {
+	current := append([]string{}, *value...)
+	expected := append([]string{}, desired...)
+	sort.Strings(current)
+	sort.Strings(expected)
+	if strings.Join(current, "\n") == strings.Join(expected, "\n") && len(current) == len(expected) {
+		return
+	}
+
+	*value = append([]string{}, desired...)
+	*fields = append(*fields, name)
+}
+
+// Apply applies the changes in order, and stops at the first failure.
+// The changes already applied are marked as such.
+func (p *Plan) Apply(client *casdoorsdk.Client) error {
+	for _, change := range p.Changes {
+		if change.Applied {
+			continue
+		}
+
+		var affected bool
+		var err error
+		switch {
+		case change.Kind == KindRole && change.Action == ActionCreate:
+			affected, err = client.AddRole(change.Role)
+		case change.Kind == KindRole && change.Action == ActionUpdate:
+			affected, err = client.UpdateRole(change.Role)
+		case change.Kind == KindRole && change.Action == ActionDelete:
+			affected, err = client.DeleteRole(change.Role)
+		case change.Kind == KindPermission && change.Action == ActionCreate:
+			affected, err = client.AddPermission(change.Permission)
+		case change.Kind == KindPermission && change.Action == ActionUpdate:
+			affected, err = client.UpdatePermission(change.Permission)
+		case change.Kind == KindPermission && change.Action == ActionDelete:
+			affected, err = client.DeletePermission(change.Permission)
+		default:
+			err = fmt.Errorf("unknown change %s of %s", change.Action, change.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Kind, change.Name, err)
+		}
+		if !affected {
+			return fmt.Errorf("failed to %s %s %s: not affected", change.Action, change.Kind, change.Name)
+		}
+		change.Applied = true
+	}
+	return nil
+}
+
+// IsEmpty returns true if the organization is already in the state of the manifest.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan for review, one line per change.
+func (p *Plan) String() string {
+	if p.IsEmpty() {
+		return "No changes, the roles and permissions are up to date.\n"
+	}
+
+	var b strings.Builder
+	counts := map[string]int{}
+	for _, change := range p.Changes {
+		counts[change.Action]++
+
+		symbol := map[string]string{ActionCreate: "+", ActionUpdate: "~", ActionDelete: "-"}[change.Action]
+		fmt.Fprintf(&b, "%s %s %s", symbol, change.Kind, change.Name)
+		if len(change.Fields) != 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(change.Fields, ", "))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.\n", counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+	return b.String()
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package permissionsync
+
+import (
+	"testing"
+
+	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
+)
+
+const testManifest = `
+roles:
+  - name: admin
+    users: [built-in/alice, built-in/bob]
+  - name: viewer
+    users: [built-in/carol]
+permissions:
+  - name: permission-data
+    roles: [built-in/admin]
+    resources: [data1]
+    actions: [Read, Write]
+    effect: Allow
+    model: model-rbac
+`
+
+func TestDiff(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Roles) != 2 || !manifest.Roles[0].IsEnabled || manifest.Permissions[0].Model != "model-rbac" {
+		t.Fatalf("Unexpected manifest %+v", manifest)
+	}
+
+	roles := []*casdoorsdk.Role{
+		{Owner: "built-in", Name: "admin", Users: []string{"built-in/bob", "built-in/alice"}, IsEnabled: true},
+		{Owner: "built-in", Name: "legacy", IsEnabled: true},
+	}
+	permissions := []*casdoorsdk.Permission{
+		{Owner: "built-in", Name: "permission-data", CreatedTime: "2023-01-01T00:00:00Z", Roles: []string{"built-in/admin"}, Resources: []string{"data1"}, Actions: []string{"Read"}, Effect: "Allow", Model: "model-rbac", IsEnabled: true},
+	}
+
+	plan := diff("built-in", manifest, roles, permissions, false)
+	expected := "+ role viewer\n~ permission permission-data (actions)\nPlan: 1 to create, 1 to update, 0 to delete.\n"
+	if plan.String() != expected {
+		t.Errorf("Expected plan:\n%s\nbut got:\n%s", expected, plan.String())
+	}
+	if plan.Changes[0].Role.Owner != "built-in" || plan.Changes[1].Permission.CreatedTime != "2023-01-01T00:00:00Z" {
+		t.Errorf("Expected the changes to keep the owner and the unmanaged fields, got %+v", plan.Changes)
+	}
+
+	plan = diff("built-in", manifest, roles, permissions, true)
+	last := plan.Changes[len(plan.Changes)-1]
+	if last.Action != ActionDelete || last.Kind != KindRole || last.Name != "legacy" {
+		t.Errorf("Expected the legacy role to be pruned, got %+v", last)
+	}
+
+	// text fields left out of the manifest keep the values set in Casdoor
+	manifest, err = ParseManifest([]byte("permissions:\n  - name: permission-data\n    roles: [built-in/admin]\n    resources: [data1]\n    actions: [Read]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	permissions[0].Description = "Data access"
+	plan = diff("built-in", manifest, nil, permissions, false)
+	if !plan.IsEmpty() {
+		t.Errorf("Expected the unspecified fields to be kept, got:\n%s", plan.String())
+	}
+
+	if _, err = ParseManifest([]byte("permissions:\n  - name: p\n    effect: Maybe\n")); err == nil {
+		t.Errorf("Expected an invalid effect to be rejected")
+	}
+}
@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -65,17 +64,13 @@ func (c *Client) GetPaginationPlans(p int, pageSize int, queryMap map[string]str
 
 	url := c.GetUrl("get-payments", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var plans []*Plan
+	total, err := c.doGetPagination(url, &plans)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	plans, ok := response.Data.([]*Plan)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return plans, int(response.Data2.(float64)), nil
+	return plans, total, nil
 }
 
 func (c *Client) GetPlan(name string) (*Plan, error) {
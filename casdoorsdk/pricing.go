@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -68,17 +67,13 @@ func (c *Client) GetPaginationPricings(p int, pageSize int, queryMap map[string]
 
 	url := c.GetUrl("get-payments", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var pricings []*Pricing
+	total, err := c.doGetPagination(url, &pricings)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	pricings, ok := response.Data.([]*Pricing)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return pricings, int(response.Data2.(float64)), nil
+	return pricings, total, nil
 }
 
 func (c *Client) GetPricing(name string) (*Pricing, error) {
@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -70,17 +69,13 @@ func (c *Client) GetPaginationProducts(p int, pageSize int, queryMap map[string]
 
 	url := c.GetUrl("get-products", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var products []*Product
+	total, err := c.doGetPagination(url, &products)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	products, ok := response.Data.([]*Product)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return products, int(response.Data2.(float64)), nil
+	return products, total, nil
 }
 
 func (c *Client) GetProduct(name string) (*Product, error) {
@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -116,15 +115,13 @@ func (c *Client) GetPaginationProviders(p int, pageSize int, queryMap map[string
 
 	url := c.GetUrl("get-providers", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var providers []*Provider
+	total, err := c.doGetPagination(url, &providers)
 	if err != nil {
 		return nil, 0, err
 	}
-	providers, ok := response.Data.([]*Provider)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-	return providers, int(response.Data2.(float64)), nil
+
+	return providers, total, nil
 }
 
 func (c *Client) UpdateProvider(provider *Provider) (bool, error) {
@@ -0,0 +1,84 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import "net/url"
+
+const (
+	SortAscend  = "ascend"
+	SortDescend = "descend"
+)
+
+// Query is a search of a paginated list of Casdoor, like GetPaginationRecords, GetPaginationPayments
+// or GetPaginationPermissions. Casdoor searches one field at a time, with a "like" on its value,
+// and sorts by one field.
+//
+//	records, count, err := client.GetPaginationRecords(1, 20, casdoorsdk.NewQuery().Search("action", "login").SortBy("createdTime", casdoorsdk.SortDescend).QueryMap())
+type Query struct {
+	Field     string
+	Value     string
+	SortField string
+	SortOrder string
+	// Params are the other parameters of the list, e.g. "groupName" for users.
+	Params map[string]string
+}
+
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Search returns the objects whose field, given by its json name, contains the value.
+func (q *Query) Search(field string, value string) *Query {
+	q.Field = field
+	q.Value = value
+	return q
+}
+
+// SortBy sorts the objects by the field, given by its json name, in the order SortAscend (the default) or SortDescend.
+func (q *Query) SortBy(field string, order string) *Query {
+	q.SortField = field
+	q.SortOrder = order
+	return q
+}
+
+// Set sets another parameter of the list.
+func (q *Query) Set(key string, value string) *Query {
+	if q.Params == nil {
+		q.Params = map[string]string{}
+	}
+	q.Params[key] = value
+	return q
+}
+
+// QueryMap compiles the query to the parameters of the list, to be passed to the GetPaginationXxx functions.
+// The values are escaped, since GetUrl adds them to the url as is.
+func (q *Query) QueryMap() map[string]string {
+	res := map[string]string{}
+	if q.Field != "" && q.Value != "" {
+		res["field"] = url.QueryEscape(q.Field)
+		res["value"] = url.QueryEscape(q.Value)
+	}
+	if q.SortField != "" {
+		res["sortField"] = url.QueryEscape(q.SortField)
+		res["sortOrder"] = SortAscend
+		if q.SortOrder != "" {
+			res["sortOrder"] = url.QueryEscape(q.SortOrder)
+		}
+	}
+	for key, value := range q.Params {
+		res[key] = url.QueryEscape(value)
+	}
+	return res
+}
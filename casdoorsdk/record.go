@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -68,17 +67,13 @@ func (c *Client) GetPaginationRecords(p int, pageSize int, queryMap map[string]s
 
 	url := c.GetUrl("get-records", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var records []*Record
+	total, err := c.doGetPagination(url, &records)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	records, ok := response.Data.([]*Record)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return records, int(response.Data2.(float64)), nil
+	return records, total, nil
 }
 
 func (c *Client) GetRecord(name string) (*Record, error) {
@@ -0,0 +1,199 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/casbin/casbin/v2/model"
+)
+
+// attributeRegex matches the attributes of request values used by matchers, e.g. "r_sub.Owner".
+var attributeRegex = regexp.MustCompile(`\br_(\w+)\.(\w+)`)
+
+// RequestDefinition is the request definition of a model, e.g. "r = sub, obj, act".
+type RequestDefinition struct {
+	// Tokens are the names of the request values, in order.
+	Tokens []string
+	// Attributes are the attributes the matchers read from ABAC request values, by token.
+	Attributes map[string][]string
+}
+
+// GetRequestDefinition returns the request definition of the model with the given id ("owner/name").
+func (c *Client) GetRequestDefinition(modelId string) (*RequestDefinition, error) {
+	modelText, err := c.getModelText(context.Background(), modelId)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRequestDefinition(modelText)
+}
+
+// ParseRequestDefinition reads the request definition and the attributes used by the matchers from a model text.
+func ParseRequestDefinition(modelText string) (*RequestDefinition, error) {
+	m, err := model.NewModelFromString(modelText)
+	if err != nil {
+		return nil, err
+	}
+
+	assertion, ok := m["r"]["r"]
+	if !ok {
+		return nil, fmt.Errorf("the model has no request definition")
+	}
+
+	definition := &RequestDefinition{Attributes: map[string][]string{}}
+	for _, token := range assertion.Tokens {
+		definition.Tokens = append(definition.Tokens, strings.TrimPrefix(token, "r_"))
+	}
+
+	for _, matcher := range m["m"] {
+		for _, match := range attributeRegex.FindAllStringSubmatch(matcher.Value, -1) {
+			token, attribute := match[1], match[2]
+			if !containsString(definition.Tokens, token) || containsString(definition.Attributes[token], attribute) {
+				continue
+			}
+			definition.Attributes[token] = append(definition.Attributes[token], attribute)
+		}
+	}
+	return definition, nil
+}
+
+// NewRequest returns a builder of requests following the definition.
+func (d *RequestDefinition) NewRequest() *RequestBuilder {
+	return &RequestBuilder{
+		definition: d,
+		values:     map[string]interface{}{},
+	}
+}
+
+// RequestBuilder builds a CasbinRequest by token name instead of position, e.g.
+// definition.NewRequest().Set("sub", user).Set("obj", path).Set("act", "GET").Build().
+type RequestBuilder struct {
+	definition *RequestDefinition
+	values     map[string]interface{}
+	err        error
+}
+
+// Set sets the value of a token. Values are strings, booleans and numbers, or structs and maps for ABAC,
+// which are sent to Casdoor as JSON objects. Errors are reported by Build.
+func (b *RequestBuilder) Set(name string, value interface{}) *RequestBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if !containsString(b.definition.Tokens, name) {
+		b.err = fmt.Errorf("%s is not defined in the request definition %s", name, strings.Join(b.definition.Tokens, ", "))
+		return b
+	}
+
+	b.err = checkRequestValue(name, value, b.definition.Attributes[name])
+	if b.err == nil {
+		b.values[name] = value
+	}
+	return b
+}
+
+// Build returns the request, or the first error met while setting its values.
+// Every token of the request definition must have been set.
+func (b *RequestBuilder) Build() (CasbinRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	request := make(CasbinRequest, len(b.definition.Tokens))
+	for i, token := range b.definition.Tokens {
+		value, ok := b.values[token]
+		if !ok {
+			return nil, fmt.Errorf("%s is not set, the request definition is %s", token, strings.Join(b.definition.Tokens, ", "))
+		}
+		request[i] = value
+	}
+	return request, nil
+}
+
+// BuildRequests builds the requests of a BatchEnforce.
+func BuildRequests(builders ...*RequestBuilder) ([]CasbinRequest, error) {
+	requests := make([]CasbinRequest, 0, len(builders))
+	for i, builder := range builders {
+		request, err := builder.Build()
+		if err != nil {
+			return nil, fmt.Errorf("request %d: %w", i, err)
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// checkRequestValue checks that value can be sent to Casdoor, and that it has the attributes read by the matchers.
+func checkRequestValue(name string, value interface{}, attributes []string) error {
+	if value == nil {
+		return fmt.Errorf("%s is nil", name)
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return fmt.Errorf("%s is nil", name)
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if len(attributes) != 0 {
+			return fmt.Errorf("%s must be a struct or a map, the matchers read its attributes %s", name, strings.Join(attributes, ", "))
+		}
+		return nil
+	case reflect.Struct:
+		// Casdoor receives the value as JSON, so the matchers read its json names
+		fields := getCachedObjectFields(v.Type())
+		for _, attribute := range attributes {
+			found := false
+			for _, field := range fields {
+				if field.name == attribute {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("%s has no field with the json name %s read by the matchers", name, attribute)
+			}
+		}
+		return nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("%s must be a map with string keys", name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s has unsupported type %T", name, value)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
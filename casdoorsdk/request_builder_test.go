@@ -0,0 +1,87 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	abacModelText := `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub.Owner == r.obj.Owner && r.act == p.act`
+
+	type subject struct {
+		Owner string
+		Name  string
+	}
+
+	rbac, err := ParseRequestDefinition(testModelText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	abac, err := ParseRequestDefinition(abacModelText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(abac.Tokens, []string{"sub", "obj", "act"}) || !reflect.DeepEqual(abac.Attributes["sub"], []string{"Owner"}) {
+		t.Fatalf("Unexpected request definition %+v", abac)
+	}
+
+	testCases := []struct {
+		builder  *RequestBuilder
+		expected CasbinRequest
+	}{
+		{
+			builder:  rbac.NewRequest().Set("act", "read").Set("sub", "built-in/alice").Set("obj", "data1"),
+			expected: CasbinRequest{"built-in/alice", "data1", "read"},
+		},
+		{
+			builder:  abac.NewRequest().Set("sub", &subject{Owner: "built-in"}).Set("obj", map[string]interface{}{"Owner": "built-in"}).Set("act", "read"),
+			expected: CasbinRequest{&subject{Owner: "built-in"}, map[string]interface{}{"Owner": "built-in"}, "read"},
+		},
+		{builder: rbac.NewRequest().Set("sub", "built-in/alice").Set("obj", "data1")},
+		{builder: rbac.NewRequest().Set("subject", "built-in/alice")},
+		{builder: rbac.NewRequest().Set("sub", []string{"built-in/alice"})},
+		{builder: abac.NewRequest().Set("sub", "built-in/alice")},
+		{builder: abac.NewRequest().Set("sub", struct{ Name string }{Name: "alice"})},
+		{builder: abac.NewRequest().Set("sub", &User{Owner: "built-in"}).Set("obj", map[string]interface{}{"Owner": "built-in"}).Set("act", "read")},
+	}
+
+	for i, tc := range testCases {
+		request, err := tc.builder.Build()
+		if tc.expected == nil {
+			if err == nil {
+				t.Errorf("Case %d: expected an error, got request %v", i, request)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Case %d: unexpected error %v", i, err)
+		} else if !reflect.DeepEqual(request, tc.expected) {
+			t.Errorf("Case %d: expected %v, but got %v", i, tc.expected, request)
+		}
+	}
+}
@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -30,6 +29,7 @@ type Role struct {
 	Description string `xorm:"varchar(100)" json:"description"`
 
 	Users     []string `xorm:"mediumtext" json:"users"`
+	Groups    []string `xorm:"mediumtext" json:"groups"`
 	Roles     []string `xorm:"mediumtext" json:"roles"`
 	Domains   []string `xorm:"mediumtext" json:"domains"`
 	IsEnabled bool     `json:"isEnabled"`
@@ -62,16 +62,13 @@ func (c *Client) GetPaginationRoles(p int, pageSize int, queryMap map[string]str
 
 	url := c.GetUrl("get-roles", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var roles []*Role
+	total, err := c.doGetPagination(url, &roles)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	roles, ok := response.Data.([]*Role)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-	return roles, int(response.Data2.(float64)), nil
+	return roles, total, nil
 }
 
 func (c *Client) GetRole(name string) (*Role, error) {
@@ -0,0 +1,340 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RoleGraph is the inheritance hierarchy of roles. A role lists in Role.Roles its sub-roles,
+// whose members inherit it, so the members of a role include those of its sub-roles.
+// A role with domains only applies in those domains, a disabled role applies nowhere
+// and nothing is inherited through it.
+type RoleGraph struct {
+	roles   map[string]*Role
+	ids     []string
+	parents map[string][]string
+}
+
+// DanglingRoleReference is a sub-role listed by a role that does not exist.
+type DanglingRoleReference struct {
+	RoleId    string `json:"roleId"`
+	Reference string `json:"reference"`
+}
+
+// GetRoleGraph builds the role graph of the organization.
+func (c *Client) GetRoleGraph() (*RoleGraph, error) {
+	roles, err := c.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRoleGraph(roles), nil
+}
+
+func NewRoleGraph(roles []*Role) *RoleGraph {
+	g := &RoleGraph{
+		roles:   map[string]*Role{},
+		parents: map[string][]string{},
+	}
+
+	for _, role := range roles {
+		id := getFullId(role.Owner, role.Name)
+		if _, ok := g.roles[id]; ok {
+			continue
+		}
+		g.roles[id] = role
+		g.ids = append(g.ids, id)
+	}
+	sort.Strings(g.ids)
+
+	for _, id := range g.ids {
+		for _, subRoleId := range g.roles[id].Roles {
+			if !containsString(g.parents[subRoleId], id) {
+				g.parents[subRoleId] = append(g.parents[subRoleId], id)
+			}
+		}
+	}
+	return g
+}
+
+// Roles returns the ids of the roles of the graph, sorted.
+func (g *RoleGraph) Roles() []string {
+	return append([]string{}, g.ids...)
+}
+
+// GetRole returns the role with the given id, nil if it is not in the graph.
+func (g *RoleGraph) GetRole(roleId string) *Role {
+	return g.roles[roleId]
+}
+
+// Members returns the users and groups having the role in domain, directly or through its sub-roles.
+// An empty domain ignores the domains of the roles.
+func (g *RoleGraph) Members(roleId string, domain string) []string {
+	var res []string
+	for _, id := range append([]string{roleId}, g.SubRoles(roleId, domain)...) {
+		role, ok := g.roles[id]
+		if !ok || !appliesIn(role, domain) {
+			continue
+		}
+
+		for _, member := range append(append([]string{}, role.Users...), role.Groups...) {
+			if !containsString(res, member) {
+				res = append(res, member)
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SubRoles returns the roles inheriting the role in domain, transitively.
+func (g *RoleGraph) SubRoles(roleId string, domain string) []string {
+	return g.walk(roleId, domain, func(id string) []string {
+		if role, ok := g.roles[id]; ok {
+			return role.Roles
+		}
+		return nil
+	})
+}
+
+// Ancestors returns the roles the role inherits in domain, transitively.
+func (g *RoleGraph) Ancestors(roleId string, domain string) []string {
+	return g.walk(roleId, domain, func(id string) []string {
+		return g.parents[id]
+	})
+}
+
+// walk returns the existing roles reachable from roleId and applying in domain, sorted, cycles are walked once.
+func (g *RoleGraph) walk(roleId string, domain string, next func(string) []string) []string {
+	if role, ok := g.roles[roleId]; ok && !role.IsEnabled {
+		return nil
+	}
+
+	visited := map[string]bool{roleId: true}
+	var res []string
+	queue := []string{roleId}
+	for len(queue) != 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		for _, nextId := range next(id) {
+			role, ok := g.roles[nextId]
+			if visited[nextId] || !ok || !appliesIn(role, domain) {
+				continue
+			}
+			visited[nextId] = true
+			res = append(res, nextId)
+			queue = append(queue, nextId)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Cycles returns the groups of roles inheriting each other, each sorted.
+func (g *RoleGraph) Cycles() [][]string {
+	// Tarjan's algorithm, every strongly connected component with more than one role,
+	// or with a role listing itself, is a cycle
+	index := 0
+	indexes := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var res [][]string
+
+	var connect func(id string)
+	connect = func(id string) {
+		indexes[id] = index
+		lowLinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, subRoleId := range g.roles[id].Roles {
+			if _, ok := g.roles[subRoleId]; !ok {
+				continue
+			}
+			if _, ok := indexes[subRoleId]; !ok {
+				connect(subRoleId)
+				if lowLinks[subRoleId] < lowLinks[id] {
+					lowLinks[id] = lowLinks[subRoleId]
+				}
+			} else if onStack[subRoleId] && indexes[subRoleId] < lowLinks[id] {
+				lowLinks[id] = indexes[subRoleId]
+			}
+		}
+
+		if lowLinks[id] != indexes[id] {
+			return
+		}
+
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == id {
+				break
+			}
+		}
+		if len(component) > 1 || containsString(g.roles[id].Roles, id) {
+			sort.Strings(component)
+			res = append(res, component)
+		}
+	}
+
+	for _, id := range g.ids {
+		if _, ok := indexes[id]; !ok {
+			connect(id)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i][0] < res[j][0]
+	})
+	return res
+}
+
+// DanglingReferences returns the sub-roles listed by the roles that do not exist.
+func (g *RoleGraph) DanglingReferences() []*DanglingRoleReference {
+	res := []*DanglingRoleReference{}
+	for _, id := range g.ids {
+		for _, subRoleId := range g.roles[id].Roles {
+			if _, ok := g.roles[subRoleId]; !ok {
+				res = append(res, &DanglingRoleReference{RoleId: id, Reference: subRoleId})
+			}
+		}
+	}
+	return res
+}
+
+// Dot renders the graph in the Graphviz DOT language, with an edge from each sub-role to the role it inherits.
+// Dangling references are dashed and the roles of cycles are red.
+func (g *RoleGraph) Dot() string {
+	inCycle := map[string]bool{}
+	for _, cycle := range g.Cycles() {
+		for _, id := range cycle {
+			inCycle[id] = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph roles {\n")
+	b.WriteString("  rankdir=BT;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, id := range g.ids {
+		role := g.roles[id]
+		label := role.Name
+		if role.DisplayName != "" {
+			label = role.DisplayName
+		}
+		if len(role.Domains) != 0 {
+			label += fmt.Sprintf("\n[%s]", strings.Join(role.Domains, ", "))
+		}
+		label += fmt.Sprintf("\n%d users, %d groups", len(role.Users), len(role.Groups))
+
+		attributes := fmt.Sprintf("label=%s", quoteDot(label))
+		if inCycle[id] {
+			attributes += ", color=red"
+		}
+		if !role.IsEnabled {
+			attributes += ", fontcolor=gray"
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", quoteDot(id), attributes)
+	}
+	for _, id := range g.ids {
+		for _, subRoleId := range g.roles[id].Roles {
+			if _, ok := g.roles[subRoleId]; ok {
+				fmt.Fprintf(&b, "  %s -> %s;\n", quoteDot(subRoleId), quoteDot(id))
+			} else {
+				fmt.Fprintf(&b, "  %s [style=dashed];\n", quoteDot(subRoleId))
+				fmt.Fprintf(&b, "  %s -> %s [style=dashed];\n", quoteDot(subRoleId), quoteDot(id))
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func quoteDot(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+type roleGraphNode struct {
+	Id        string   `json:"id"`
+	IsEnabled bool     `json:"isEnabled"`
+	Domains   []string `json:"domains"`
+	Users     []string `json:"users"`
+	Groups    []string `json:"groups"`
+	SubRoles  []string `json:"subRoles"`
+	Ancestors []string `json:"ancestors"`
+	Members   []string `json:"members"`
+}
+
+// MarshalJSON renders the graph with the transitive members and ancestors of each role, ignoring domains.
+func (g *RoleGraph) MarshalJSON() ([]byte, error) {
+	nodes := []*roleGraphNode{}
+	for _, id := range g.ids {
+		role := g.roles[id]
+		nodes = append(nodes, &roleGraphNode{
+			Id:        id,
+			IsEnabled: role.IsEnabled,
+			Domains:   nonNilStrings(role.Domains),
+			Users:     nonNilStrings(role.Users),
+			Groups:    nonNilStrings(role.Groups),
+			SubRoles:  nonNilStrings(role.Roles),
+			Ancestors: nonNilStrings(g.Ancestors(id, "")),
+			Members:   nonNilStrings(g.Members(id, "")),
+		})
+	}
+
+	cycles := g.Cycles()
+	if cycles == nil {
+		cycles = [][]string{}
+	}
+
+	return json.Marshal(struct {
+		Roles              []*roleGraphNode         `json:"roles"`
+		Cycles             [][]string               `json:"cycles"`
+		DanglingReferences []*DanglingRoleReference `json:"danglingReferences"`
+	}{
+		Roles:              nodes,
+		Cycles:             cycles,
+		DanglingReferences: g.DanglingReferences(),
+	})
+}
+
+// appliesIn returns true if the role is enabled and applies in domain, an empty domain matches every role.
+func appliesIn(role *Role, domain string) bool {
+	if !role.IsEnabled {
+		return false
+	}
+	return domain == "" || len(role.Domains) == 0 || containsString(role.Domains, domain)
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoleGraph(t *testing.T) {
+	graph := NewRoleGraph([]*Role{
+		{Owner: "built-in", Name: "admin", Users: []string{"built-in/alice"}, Roles: []string{"built-in/editor", "built-in/legacy", "built-in/missing"}, IsEnabled: true},
+		{Owner: "built-in", Name: "editor", Users: []string{"built-in/bob"}, Roles: []string{"built-in/viewer"}, Domains: []string{"domain1"}, IsEnabled: true},
+		{Owner: "built-in", Name: "viewer", Groups: []string{"built-in/dev"}, IsEnabled: true},
+		{Owner: "built-in", Name: "legacy", Users: []string{"built-in/carol"}, Roles: []string{"built-in/viewer"}},
+		{Owner: "built-in", Name: "a", Roles: []string{"built-in/b"}, IsEnabled: true},
+		{Owner: "built-in", Name: "b", Roles: []string{"built-in/a"}, IsEnabled: true},
+		{Owner: "built-in", Name: "self", Roles: []string{"built-in/self"}, IsEnabled: true},
+	})
+
+	testCases := []struct {
+		name     string
+		actual   []string
+		expected []string
+	}{
+		{"members", graph.Members("built-in/admin", ""), []string{"built-in/alice", "built-in/bob", "built-in/dev"}},
+		{"members in domain1", graph.Members("built-in/admin", "domain1"), []string{"built-in/alice", "built-in/bob", "built-in/dev"}},
+		{"members in domain2", graph.Members("built-in/admin", "domain2"), []string{"built-in/alice"}},
+		{"ancestors", graph.Ancestors("built-in/viewer", ""), []string{"built-in/admin", "built-in/editor"}},
+		{"ancestors in domain2", graph.Ancestors("built-in/viewer", "domain2"), nil},
+		{"cyclic ancestors", graph.Ancestors("built-in/a", ""), []string{"built-in/b"}},
+		{"members of a disabled role", graph.Members("built-in/legacy", ""), nil},
+		{"ancestors of a disabled role", graph.Ancestors("built-in/legacy", ""), nil},
+	}
+	for _, tc := range testCases {
+		if !reflect.DeepEqual(tc.actual, tc.expected) {
+			t.Errorf("For %s, expected %v, but got %v", tc.name, tc.expected, tc.actual)
+		}
+	}
+
+	cycles := graph.Cycles()
+	if !reflect.DeepEqual(cycles, [][]string{{"built-in/a", "built-in/b"}, {"built-in/self"}}) {
+		t.Errorf("Unexpected cycles %v", cycles)
+	}
+
+	dangling := graph.DanglingReferences()
+	if len(dangling) != 1 || dangling[0].RoleId != "built-in/admin" || dangling[0].Reference != "built-in/missing" {
+		t.Errorf("Unexpected dangling references %+v", dangling)
+	}
+
+	dot := graph.Dot()
+	if !strings.HasPrefix(dot, "digraph roles {") || !strings.Contains(dot, `"built-in/editor" -> "built-in/admin";`) {
+		t.Errorf("Unexpected DOT output %s", dot)
+	}
+
+	bytes, err := json.Marshal(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		Roles  []roleGraphNode `json:"roles"`
+		Cycles [][]string      `json:"cycles"`
+	}
+	if err = json.Unmarshal(bytes, &document); err != nil || len(document.Roles) != 7 || len(document.Cycles) != 2 {
+		t.Errorf("Unexpected JSON output %s", bytes)
+	}
+}
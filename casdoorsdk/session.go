@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -62,16 +61,13 @@ func (c *Client) GetPaginationSessions(p int, pageSize int, queryMap map[string]
 
 	url := c.GetUrl("get-sessions", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var sessions []*Session
+	total, err := c.doGetPagination(url, &sessions)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	sessions, ok := response.Data.([]*Session)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-	return sessions, int(response.Data2.(float64)), nil
+	return sessions, total, nil
 }
 
 func (c *Client) GetSession(name string) (*Session, error) {
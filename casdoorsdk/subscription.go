@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -70,17 +69,15 @@ func (c *Client) GetPaginationSubscriptions(p int, pageSize int, queryMap map[st
 	queryMap["p"] = strconv.Itoa(p)
 	queryMap["pageSize"] = strconv.Itoa(pageSize)
 
-	url := c.GetUrl("get-providers", queryMap)
+	url := c.GetUrl("get-subscriptions", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var subscriptions []*Subscription
+	total, err := c.doGetPagination(url, &subscriptions)
 	if err != nil {
 		return nil, 0, err
 	}
-	subscriptions, ok := response.Data.([]*Subscription)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-	return subscriptions, int(response.Data2.(float64)), nil
+
+	return subscriptions, total, nil
 }
 
 func (c *Client) GetSubscription(name string) (*Subscription, error) {
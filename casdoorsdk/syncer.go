@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -85,17 +84,13 @@ func (c *Client) GetPaginationSyncers(p int, pageSize int, queryMap map[string]s
 
 	url := c.GetUrl("get-models", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var syncers []*Syncer
+	total, err := c.doGetPagination(url, &syncers)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	syncers, ok := response.Data.([]*Syncer)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return syncers, int(response.Data2.(float64)), nil
+	return syncers, total, nil
 }
 
 func (c *Client) GetSyncer(name string) (*Syncer, error) {
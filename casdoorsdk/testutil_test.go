@@ -0,0 +1,47 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeHandler answers a request to the fake Casdoor server with the data of the response. A returned error
+// is sent as a response with status "error", a *Response is sent as is.
+type fakeHandler func(r *http.Request) (interface{}, error)
+
+// newFakeClient starts a fake Casdoor server answering with the handler, closed when the test ends, and
+// returns a client of the "built-in" organization connected to it. It is casdoortest.NewServer for the
+// tests of this package, which cannot import casdoortest.
+func newFakeClient(t testing.TB, handler fakeHandler) *Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := handler(r)
+
+		response, ok := data.(*Response)
+		if !ok {
+			response = &Response{Status: "ok", Data: data}
+		}
+		if err != nil {
+			response = &Response{Status: "error", Msg: err.Error()}
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(ts.Close)
+
+	return NewClient(ts.URL, "client-id", "client-secret", "", "built-in", "app-built-in")
+}
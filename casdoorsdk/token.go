@@ -107,17 +107,13 @@ func (c *Client) GetTokens(p int, pageSize int) ([]*Token, int, error) {
 
 	url := c.GetUrl("get-tokens", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var tokens []*Token
+	total, err := c.doGetPagination(url, &tokens)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	tokens, ok := response.Data.([]*Token)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return tokens, int(response.Data2.(float64)), nil
+	return tokens, total, nil
 }
 
 func (c *Client) DeleteToken(name string) (bool, error) {
@@ -0,0 +1,125 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The TOTP parameters of Casdoor, the defaults of authenticator apps: HMAC-SHA1, 6 digits and a period of 30 seconds.
+const (
+	totpSecretSize = 20
+	totpDigits     = 6
+	totpPeriod     = 30
+	// totpSkew is the number of periods before and after the current one whose codes are still valid.
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random TOTP secret, base32 encoded like in otpauth URLs.
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+func decodeTotpSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	return key, nil
+}
+
+// getHotpCode computes the HOTP code of the counter, see https://www.rfc-editor.org/rfc/rfc4226#section-5.3.
+func getHotpCode(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// GenerateTotpCode returns the TOTP code of the secret at the time, as defined by RFC 6238.
+func GenerateTotpCode(secret string, t time.Time) (string, error) {
+	key, err := decodeTotpSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return getHotpCode(key, uint64(t.Unix())/totpPeriod), nil
+}
+
+// ValidateTotpCode returns true if the code is the TOTP code of the secret at the time, or one period before or after
+// it, to allow for clock drift and for the time the user takes to enter the code. A code stays valid for its whole
+// window and nothing prevents its reuse, so callers must remember the codes already used to reject replays.
+func ValidateTotpCode(secret string, code string, t time.Time) bool {
+	key, err := decodeTotpSecret(secret)
+	if err != nil || len(code) != totpDigits {
+		return false
+	}
+
+	counter := uint64(t.Unix()) / totpPeriod
+	for i := -totpSkew; i <= totpSkew; i++ {
+		expected := getHotpCode(key, counter+uint64(i))
+		if subtle.ConstantTimeCompare([]byte(code), []byte(expected)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTotpUrl returns the otpauth URL of the secret to show as a QR code to authenticator apps, see
+// https://github.com/google/google-authenticator/wiki/Key-Uri-Format. Casdoor uses the organization
+// as issuer and the user name as account name.
+func GetTotpUrl(issuer string, accountName string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", totpPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: params.Encode(),
+	}
+	return u.String()
+}
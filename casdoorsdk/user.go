@@ -15,6 +15,7 @@
 package casdoorsdk
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,6 +24,9 @@ import (
 
 const MfaRecoveryCodesSession = "mfa_recovery_codes"
 
+// ErrUserChanged is returned by UpdateUserChanged when the user has been updated since it was loaded.
+var ErrUserChanged = errors.New("the user has been updated since it was loaded")
+
 type ManagedAccount struct {
 	Application string `xorm:"varchar(100)" json:"application"`
 	Username    string `xorm:"varchar(100)" json:"username"`
@@ -265,23 +269,22 @@ func (c *Client) GetSortedUsers(sorter string, limit int) ([]*User, error) {
 }
 
 func (c *Client) GetPaginationUsers(p int, pageSize int, queryMap map[string]string) ([]*User, int, error) {
+	if queryMap == nil {
+		queryMap = map[string]string{}
+	}
 	queryMap["owner"] = c.OrganizationName
 	queryMap["p"] = strconv.Itoa(p)
 	queryMap["pageSize"] = strconv.Itoa(pageSize)
 
 	url := c.GetUrl("get-users", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var users []*User
+	total, err := c.doGetPagination(url, &users)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	users, ok := response.Data.([]*User)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return users, int(response.Data2.(float64)), nil
+	return users, total, nil
 }
 
 func (c *Client) GetUserCount(isOnline string) (int, error) {
@@ -389,7 +392,14 @@ func (c *Client) GetUserByUserId(userId string) (*User, error) {
 }
 
 // note: oldPassword is not required, if you don't need, just pass a empty string
+// The new password is checked against the cached password options of the organization first, see ValidatePassword,
+// and only by Casdoor when the organization cannot be fetched.
 func (c *Client) SetPassword(owner, name, oldPassword, newPassword string) (bool, error) {
+	err := c.validateUserPassword(owner, newPassword)
+	if err != nil {
+		return false, err
+	}
+
 	param := map[string]string{
 		"userOwner":   owner,
 		"userName":    name,
@@ -425,7 +435,57 @@ func (c *Client) UpdateUserForColumns(user *User, columns []string) (bool, error
 	return affected, err
 }
 
+// UpdateUserChanged updates only the fields that differ between before, the user as it was loaded, and after,
+// see ChangedColumns. Nothing is sent when no field changed. Edits made meanwhile are detected by comparing
+// the UpdatedTime of before with the one of the user in Casdoor, the update then fails with ErrUserChanged
+// and the caller should load the user again. An edit made between this check and the update is not detected.
+func (c *Client) UpdateUserChanged(ctx context.Context, before *User, after *User) (bool, error) {
+	columns, err := ChangedColumns(before, after)
+	if err != nil {
+		return false, err
+	}
+
+	// Casdoor sets the updated time itself
+	for i, column := range columns {
+		if column == "updated_time" {
+			columns = append(columns[:i], columns[i+1:]...)
+			break
+		}
+	}
+	if len(columns) == 0 {
+		return false, nil
+	}
+
+	if err = ctx.Err(); err != nil {
+		return false, err
+	}
+	var current *User
+	err = c.doGetWithContext(ctx, "get-user", map[string]string{"id": c.GetId(before.Name)}, &current)
+	if err != nil {
+		return false, err
+	}
+	if current == nil {
+		return false, fmt.Errorf("user %s does not exist", c.GetId(before.Name))
+	}
+	if current.UpdatedTime != before.UpdatedTime {
+		return false, fmt.Errorf("%w: updated at %s, loaded at %s", ErrUserChanged, current.UpdatedTime, before.UpdatedTime)
+	}
+
+	_, affected, err := c.modifyUserByIdWithContext(ctx, "update-user", c.GetId(before.Name), after, columns)
+	return affected, err
+}
+
+// AddUser checks the password against the cached password options of the organization first, see ValidatePassword,
+// and leaves it to Casdoor when the organization cannot be fetched.
+// Hashed passwords, whose PasswordType is not "plain", are not checked.
 func (c *Client) AddUser(user *User) (bool, error) {
+	if user.Password != "" && (user.PasswordType == "" || user.PasswordType == "plain") {
+		err := c.validateUserPassword(c.OrganizationName, user.Password)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	_, affected, err := c.modifyUser("add-user", user, nil)
 	return affected, err
 }
@@ -0,0 +1,186 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const exportUsersPageSize = 100
+
+// userSecretFields are the json names of the fields ExportUsers redacts by default.
+var userSecretFields = []string{"password", "passwordSalt", "totpSecret", "recoveryCodes", "accessSecret"}
+
+type UserFieldSelection struct {
+	// Fields are the json names of the exported fields, e.g. "name" or "github", or properties keys like
+	// "properties.department". When empty, CSV and TSV get every text, number, boolean and list field,
+	// JSONL gets every field.
+	Fields []string
+	// IncludeSecrets exports the password, password salt, TOTP secret, recovery codes and access secret,
+	// which are left out otherwise.
+	IncludeSecrets bool
+}
+
+// ExportUsers writes the users of the organization of the client as CSV, TSV or JSONL, one row per user.
+// The users are read page by page and written as they come, they are never all in memory. The filter holds
+// parameters of get-users, like the queryMap of GetPaginationUsers, every user is exported when it is nil.
+// A failure may happen after some rows have been written.
+func (c *Client) ExportUsers(ctx context.Context, writer io.Writer, format string, fieldSelection UserFieldSelection, filter map[string]string) error {
+	return c.exportUsers(ctx, writer, format, fieldSelection, filter, nil)
+}
+
+// ExportUsersByQuery is ExportUsers for the users matching the query, every user when it is nil.
+func (c *Client) ExportUsersByQuery(ctx context.Context, writer io.Writer, format string, fieldSelection UserFieldSelection, query *UserQuery) error {
+	if query == nil {
+		query = NewUserQuery()
+	}
+	return c.exportUsers(ctx, writer, format, fieldSelection, query.getUsersQueryMap(), query.Match)
+}
+
+func (c *Client) exportUsers(ctx context.Context, writer io.Writer, format string, fieldSelection UserFieldSelection, queryMap map[string]string, match func(user *User) bool) error {
+	fields, err := getExportFields(format, fieldSelection)
+	if err != nil {
+		return err
+	}
+
+	var write func(user *User) error
+	var flush func() error
+	switch format {
+	case UserFormatCSV, UserFormatTSV:
+		w := csv.NewWriter(writer)
+		if format == UserFormatTSV {
+			w.Comma = '\t'
+		}
+		err = w.Write(fields)
+		if err != nil {
+			return err
+		}
+
+		write = func(user *User) error {
+			record := make([]string, len(fields))
+			for i, field := range fields {
+				text, err := getUserFieldText(user, field)
+				if err != nil {
+					return err
+				}
+				record[i] = text
+			}
+			return w.Write(record)
+		}
+		flush = func() error {
+			w.Flush()
+			return w.Error()
+		}
+	case UserFormatJSONL:
+		encoder := json.NewEncoder(writer)
+		write = func(user *User) error {
+			object, err := getUserObject(user, fields)
+			if err != nil {
+				return err
+			}
+			return encoder.Encode(object)
+		}
+		flush = func() error { return nil }
+	default:
+		return fmt.Errorf("unsupported user format %s", format)
+	}
+
+	return c.forEachUser(ctx, queryMap, match, exportUsersPageSize, func(users []*User) error {
+		for _, user := range users {
+			err := write(user)
+			if err != nil {
+				return err
+			}
+		}
+		return flush()
+	})
+}
+
+func getExportFields(format string, fieldSelection UserFieldSelection) ([]string, error) {
+	isSecret := func(name string) bool {
+		for _, secret := range userSecretFields {
+			if strings.EqualFold(name, secret) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(fieldSelection.Fields) == 0 {
+		var fields []string
+		for _, field := range userFields {
+			if !fieldSelection.IncludeSecrets && isSecret(field.name) {
+				continue
+			}
+			isText := field.kind == reflect.String || field.kind == reflect.Bool || field.kind == reflect.Int ||
+				field.kind == reflect.Slice && reflect.TypeOf(User{}).Field(field.index).Type.Elem().Kind() == reflect.String
+			if format == UserFormatJSONL || isText {
+				fields = append(fields, field.name)
+			}
+		}
+		return fields, nil
+	}
+
+	for _, name := range fieldSelection.Fields {
+		if strings.HasPrefix(strings.ToLower(name), userPropertiesPrefix) {
+			continue
+		}
+		_, err := getUserField(name)
+		if err != nil {
+			return nil, err
+		}
+		if !fieldSelection.IncludeSecrets && isSecret(name) {
+			return nil, fmt.Errorf("%s is a secret, set IncludeSecrets to export it", name)
+		}
+	}
+	return fieldSelection.Fields, nil
+}
+
+// getUserObject returns the fields of the user keyed by their json names, properties keys are kept under "properties".
+func getUserObject(user *User, fields []string) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+	var properties map[string]string
+	for _, name := range fields {
+		value, err := getUserFieldValue(user, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(strings.ToLower(name), userPropertiesPrefix) {
+			if properties == nil {
+				properties = map[string]string{}
+			}
+			properties[name[len(userPropertiesPrefix):]] = value.(string)
+			continue
+		}
+		res[name] = value
+	}
+
+	if properties != nil {
+		if all, ok := res["properties"].(map[string]string); ok {
+			for key, value := range all {
+				properties[key] = value
+			}
+		}
+		res["properties"] = properties
+	}
+	return res, nil
+}
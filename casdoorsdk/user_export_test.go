@@ -0,0 +1,98 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExportUsers(t *testing.T) {
+	var users []*User
+	for i := 0; i < 150; i++ {
+		users = append(users, &User{
+			Owner:      "built-in",
+			Name:       fmt.Sprintf("user%d", i),
+			Password:   "secret",
+			GitHub:     fmt.Sprintf("gh%d", i),
+			IsAdmin:    i == 0,
+			Tag:        "staff",
+			Groups:     []string{"built-in/a", "built-in/b"},
+			Properties: map[string]string{"department": "sales"},
+		})
+	}
+
+	var pages []string
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		query := r.URL.Query()
+		pages = append(pages, query.Get("p"))
+		if query.Get("sortField") != "createdTime" || query.Get("field") != "tag" {
+			t.Errorf("Unexpected query %v", query)
+		}
+
+		p, _ := strconv.Atoi(query.Get("p"))
+		pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+		start, end := (p-1)*pageSize, p*pageSize
+		if end > len(users) {
+			end = len(users)
+		}
+		return &Response{Status: "ok", Data: users[start:end], Data2: len(users)}, nil
+	})
+	query := NewUserQuery().WithTag("staff")
+
+	var b bytes.Buffer
+	err := client.ExportUsersByQuery(context.Background(), &b, UserFormatCSV, UserFieldSelection{}, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 151 || strings.Join(pages, ",") != "1,2" {
+		t.Fatalf("Expected 150 users in 2 pages, got %d lines in pages %v", len(lines)-1, pages)
+	}
+	if strings.Contains(lines[0], ",password,") || strings.Contains(lines[0], ",roles,") || !strings.Contains(lines[0], "github") {
+		t.Errorf("Unexpected default fields %s", lines[0])
+	}
+
+	b.Reset()
+	selection := UserFieldSelection{Fields: []string{"name", "GitHub", "isAdmin", "groups", "properties.department"}}
+	err = client.ExportUsersByQuery(context.Background(), &b, UserFormatTSV, selection, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(b.String(), "\n")
+	if lines[0] != "name\tGitHub\tisAdmin\tgroups\tproperties.department" || lines[1] != "user0\tgh0\ttrue\tbuilt-in/a;built-in/b\tsales" {
+		t.Errorf("Unexpected rows:\n%s\n%s", lines[0], lines[1])
+	}
+
+	b.Reset()
+	selection = UserFieldSelection{Fields: []string{"name", "properties.department"}}
+	err = client.ExportUsers(context.Background(), &b, UserFormatJSONL, selection, map[string]string{"field": "tag", "value": "staff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line, _ := b.ReadString('\n'); line != "{\"name\":\"user0\",\"properties\":{\"department\":\"sales\"}}\n" {
+		t.Errorf("Unexpected row %s", line)
+	}
+
+	err = client.ExportUsersByQuery(context.Background(), &b, UserFormatCSV, UserFieldSelection{Fields: []string{"name", "password"}}, query)
+	if err == nil {
+		t.Errorf("Expected the password to be redacted")
+	}
+}
@@ -14,6 +14,8 @@
 
 package casdoorsdk
 
+import "context"
+
 func GetGlobalUsers() ([]*User, error) {
 	return globalClient.GetGlobalUsers()
 }
@@ -67,6 +69,10 @@ func UpdateUserForColumns(user *User, columns []string) (bool, error) {
 	return globalClient.UpdateUserForColumns(user, columns)
 }
 
+func UpdateUserChanged(ctx context.Context, before *User, after *User) (bool, error) {
+	return globalClient.UpdateUserChanged(ctx, before, after)
+}
+
 func AddUser(user *User) (bool, error) {
 	return globalClient.AddUser(user)
 }
@@ -0,0 +1,421 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	UserFormatCSV   = "csv"
+	UserFormatTSV   = "tsv"
+	UserFormatJSONL = "jsonl"
+)
+
+const (
+	ImportStatusCreated = "created"
+	ImportStatusUpdated = "updated"
+	ImportStatusSkipped = "skipped"
+	ImportStatusFailed  = "failed"
+)
+
+const (
+	defaultImportBatchSize   = 100
+	defaultImportConcurrency = 4
+	maxImportLineSize        = 1024 * 1024
+)
+
+var (
+	// userNameRegex is the rule of Casdoor for user names: alphanumeric characters, separated by single "-" or "_".
+	userNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]+((?:-[a-zA-Z0-9]+)|(?:_[a-zA-Z0-9]+))*$`)
+	phoneRegex    = regexp.MustCompile(`^[0-9]{4,20}$`)
+)
+
+type ImportUsersOptions struct {
+	// Columns maps the CSV headers to user fields given by their json names, e.g. "E-mail": "email",
+	// or to properties keys, e.g. "Department": "properties.department". Headers mapped to "" are ignored,
+	// the other headers are used as field names.
+	Columns map[string]string
+	// Update updates the imported fields of the users that already exist, they are skipped otherwise.
+	Update bool
+	// BatchSize is the number of rows of a batch, it defaults to 100.
+	BatchSize int
+	// Concurrency is the number of batches upserted at the same time, it defaults to 4.
+	Concurrency int
+}
+
+// ImportUserResult is the outcome of a row of ImportUsers.
+type ImportUserResult struct {
+	// Row is the line of the user in the input.
+	Row    int
+	Name   string
+	Status string
+	// Reason explains why the user was skipped or failed.
+	Reason string
+}
+
+type ImportUsersReport struct {
+	// Results has one result per row, in the order of the input.
+	Results []*ImportUserResult
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+}
+
+type importRow struct {
+	result *ImportUserResult
+	user   *User
+	// fields are the json names of the fields given by the row, or properties keys like "properties.department"
+	fields   []string
+	existing *User
+}
+
+// ImportUsers reads users from CSV, TSV or JSONL and upserts them into the organization of the client.
+// The rows are validated locally first: names, emails, phones and the password options of the organization.
+// The existing users are then read page by page, and the valid rows are upserted in batches of BatchSize rows,
+// Concurrency batches at a time, each user of a batch being added or updated by its own request, since
+// Casdoor has no bulk user API. The report tells what happened to each row. The error is only set when
+// the input cannot be read or ctx is done, the rows not imported yet are then reported as failed.
+func (c *Client) ImportUsers(ctx context.Context, reader io.Reader, format string, options ImportUsersOptions) (*ImportUsersReport, error) {
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultImportBatchSize
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultImportConcurrency
+	}
+
+	var rows []*importRow
+	var err error
+	switch format {
+	case UserFormatCSV, UserFormatTSV:
+		rows, err = readCsvUsers(reader, format, options.Columns)
+	case UserFormatJSONL:
+		rows, err = readJsonlUsers(reader)
+	default:
+		return nil, fmt.Errorf("unsupported user format %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := c.getOrganization(ctx, fmt.Sprintf("admin/%s", c.OrganizationName))
+	if err != nil {
+		return nil, err
+	}
+
+	var valid []*importRow
+	nameRows := map[string]int{}
+	for _, row := range rows {
+		if row.result.Status != "" {
+			continue
+		}
+
+		err = validateImportUser(row.user, organization)
+		if err != nil {
+			row.result.Status, row.result.Reason = ImportStatusFailed, err.Error()
+			continue
+		}
+
+		if previous, ok := nameRows[row.user.Name]; ok {
+			row.result.Status, row.result.Reason = ImportStatusSkipped, fmt.Sprintf("duplicate of row %d", previous)
+			continue
+		}
+		nameRows[row.user.Name] = row.result.Row
+		valid = append(valid, row)
+	}
+
+	// only the existing users imported again are kept
+	userMap := map[string]*User{}
+	err = c.forEachUser(ctx, map[string]string{}, func(user *User) bool {
+		_, ok := nameRows[user.Name]
+		return ok
+	}, options.BatchSize, func(users []*User) error {
+		for _, user := range users {
+			userMap[user.Name] = user
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*importRow
+	for _, row := range valid {
+		row.existing = userMap[row.user.Name]
+		if row.existing != nil && !options.Update {
+			row.result.Status, row.result.Reason = ImportStatusSkipped, "user already exists"
+			continue
+		}
+		pending = append(pending, row)
+	}
+
+	batchChan := make(chan []*importRow)
+	wg := sync.WaitGroup{}
+	for i := 0; i < options.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batchChan {
+				for _, row := range batch {
+					c.importUser(ctx, row)
+				}
+			}
+		}()
+	}
+	for start := 0; start < len(pending); start += options.BatchSize {
+		end := start + options.BatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batchChan <- pending[start:end]
+	}
+	close(batchChan)
+	wg.Wait()
+
+	report := &ImportUsersReport{}
+	for _, row := range rows {
+		report.Results = append(report.Results, row.result)
+		switch row.result.Status {
+		case ImportStatusCreated:
+			report.Created++
+		case ImportStatusUpdated:
+			report.Updated++
+		case ImportStatusSkipped:
+			report.Skipped++
+		case ImportStatusFailed:
+			report.Failed++
+		}
+	}
+	return report, ctx.Err()
+}
+
+// importUser creates the user of the row, or updates the fields of the row on the existing user.
+func (c *Client) importUser(ctx context.Context, row *importRow) {
+	if ctx.Err() != nil {
+		row.result.Status, row.result.Reason = ImportStatusFailed, ctx.Err().Error()
+		return
+	}
+
+	var affected bool
+	var err error
+	if row.existing == nil {
+		// the password has been validated already
+		row.result.Status = ImportStatusCreated
+		_, affected, err = c.modifyUserWithContext(ctx, "add-user", row.user, nil)
+	} else {
+		row.result.Status = ImportStatusUpdated
+		user := *row.existing
+		var columns []string
+		columns, err = mergeUserFields(&user, row.user, row.fields)
+		if err == nil {
+			_, affected, err = c.modifyUserWithContext(ctx, "update-user", &user, columns)
+		}
+	}
+
+	if err != nil {
+		row.result.Status, row.result.Reason = ImportStatusFailed, err.Error()
+	} else if !affected && row.existing == nil {
+		row.result.Status, row.result.Reason = ImportStatusFailed, "user not added"
+	}
+}
+
+// mergeUserFields copies the fields from src to dst, merging the properties keys,
+// and returns the database columns of the fields.
+func mergeUserFields(dst *User, src *User, fields []string) ([]string, error) {
+	var columns []string
+	properties := map[string]string{}
+	for key, value := range dst.Properties {
+		properties[key] = value
+	}
+
+	for _, name := range fields {
+		column := "properties"
+		if strings.HasPrefix(strings.ToLower(name), userPropertiesPrefix) {
+			key := name[len(userPropertiesPrefix):]
+			properties[key] = src.Properties[key]
+		} else if strings.ToLower(name) == "properties" {
+			for key, value := range src.Properties {
+				properties[key] = value
+			}
+		} else {
+			field, err := getUserField(name)
+			if err != nil {
+				return nil, err
+			}
+			reflect.ValueOf(dst).Elem().Field(field.index).Set(reflect.ValueOf(src).Elem().Field(field.index))
+			column = field.column
+		}
+
+		if !containsString(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	dst.Properties = properties
+	return columns, nil
+}
+
+func validateImportUser(user *User, organization *Organization) error {
+	if user.Name == "" {
+		return errors.New("name is required")
+	}
+	if !userNameRegex.MatchString(user.Name) {
+		return fmt.Errorf("invalid name %s, it may only contain alphanumeric characters separated by single \"-\" or \"_\"", user.Name)
+	}
+
+	if user.Email != "" {
+		address, err := mail.ParseAddress(user.Email)
+		if err != nil || address.Address != user.Email {
+			return fmt.Errorf("invalid email %s", user.Email)
+		}
+	}
+
+	if user.Phone != "" && !phoneRegex.MatchString(user.Phone) {
+		return fmt.Errorf("invalid phone %s, it must have 4 to 20 digits", user.Phone)
+	}
+
+	// hashed passwords are imported as is
+	if user.Password != "" && (user.PasswordType == "" || user.PasswordType == "plain") {
+		violations := ValidatePassword(organization, user.Password)
+		if len(violations) != 0 {
+			return PasswordViolations(violations)
+		}
+	}
+	return nil
+}
+
+func readCsvUsers(reader io.Reader, format string, columns map[string]string) ([]*importRow, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	if format == UserFormatTSV {
+		r.Comma = '\t'
+	}
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if field, ok := columns[name]; ok {
+			name = field
+		}
+		if name != "" && !strings.HasPrefix(strings.ToLower(name), userPropertiesPrefix) {
+			_, err = getUserField(name)
+			if err != nil {
+				return nil, fmt.Errorf("column %d: %w", i+1, err)
+			}
+		}
+		fields[i] = name
+	}
+
+	var rows []*importRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := r.FieldPos(0)
+		row := &importRow{result: &ImportUserResult{Row: line}, user: &User{}}
+		rows = append(rows, row)
+		if len(record) != len(header) {
+			row.result.Status, row.result.Reason = ImportStatusFailed, fmt.Sprintf("expected %d fields, got %d", len(header), len(record))
+			continue
+		}
+
+		for i, value := range record {
+			if fields[i] == "" {
+				continue
+			}
+			err = setUserField(row.user, fields[i], strings.TrimSpace(value))
+			if err != nil {
+				row.result.Status, row.result.Reason = ImportStatusFailed, err.Error()
+				break
+			}
+			row.fields = append(row.fields, fields[i])
+		}
+		row.result.Name = row.user.Name
+	}
+	return rows, nil
+}
+
+func readJsonlUsers(reader io.Reader) ([]*importRow, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+
+	var rows []*importRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := &importRow{result: &ImportUserResult{Row: line}, user: &User{}}
+		rows = append(rows, row)
+
+		var object map[string]json.RawMessage
+		err := json.Unmarshal(text, &object)
+		if err == nil {
+			err = json.Unmarshal(text, row.user)
+		}
+		if err != nil {
+			row.result.Status, row.result.Reason = ImportStatusFailed, err.Error()
+			continue
+		}
+		row.result.Name = row.user.Name
+
+		for _, name := range getSortedRawKeys(object) {
+			_, err = getUserField(name)
+			if err != nil {
+				row.result.Status, row.result.Reason = ImportStatusFailed, err.Error()
+				break
+			}
+			row.fields = append(row.fields, name)
+		}
+	}
+	return rows, scanner.Err()
+}
+
+func getSortedRawKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestImportUsers(t *testing.T) {
+	var mutex sync.Mutex
+	added := map[string]*User{}
+	var updated *User
+	var updatedColumns string
+	var pages []string
+	existing := []*User{
+		{Owner: "built-in", Name: "zoe"},
+		{Owner: "built-in", Name: "bob", Email: "bob@example.com", Properties: map[string]string{"team": "blue"}},
+		{Owner: "built-in", Name: "yann"},
+	}
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.URL.Path {
+		case "/api/get-organization":
+			return &Organization{Owner: "admin", Name: "built-in", PasswordOptions: []string{"AtLeast8"}}, nil
+		case "/api/get-users":
+			p, _ := strconv.Atoi(r.URL.Query().Get("p"))
+			pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+			pages = append(pages, r.URL.Query().Get("p"))
+			start, end := (p-1)*pageSize, p*pageSize
+			if end > len(existing) {
+				end = len(existing)
+			}
+			return &Response{Status: "ok", Data: existing[start:end], Data2: len(existing)}, nil
+		case "/api/add-user":
+			var user User
+			_ = json.NewDecoder(r.Body).Decode(&user)
+			added[user.Name] = &user
+			return "Affected", nil
+		case "/api/update-user":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			updatedColumns = r.URL.Query().Get("columns")
+			return "Affected", nil
+		}
+		return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+	})
+
+	input := `Name,E-mail,phone,Password,Department,isAdmin
+alice,alice@example.com,12345678,secret-password,sales,true
+bob,bob@example.org,,,support,
+bad name!,x@example.com,,,,
+carol,carol-at-example.com,,,,
+dave,,,short,,
+alice,alice2@example.com,,,,
+erin,erin@example.com
+frank,,,,,maybe
+`
+	options := ImportUsersOptions{
+		Columns:   map[string]string{"E-mail": "email", "Department": "properties.department"},
+		Update:    true,
+		BatchSize: 2,
+	}
+	report, err := client.ImportUsers(context.Background(), strings.NewReader(input), UserFormatCSV, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		row    int
+		status string
+		reason string
+	}{
+		{2, ImportStatusCreated, ""},
+		{3, ImportStatusUpdated, ""},
+		{4, ImportStatusFailed, "invalid name"},
+		{5, ImportStatusFailed, "invalid email"},
+		{6, ImportStatusFailed, "at least 8 characters"},
+		{7, ImportStatusSkipped, "duplicate of row 2"},
+		{8, ImportStatusFailed, "expected 6 fields, got 2"},
+		{9, ImportStatusFailed, "isAdmin is not a boolean"},
+	}
+	if len(report.Results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d", len(expected), len(report.Results))
+	}
+	for i, result := range report.Results {
+		if result.Row != expected[i].row || result.Status != expected[i].status || !strings.Contains(result.Reason, expected[i].reason) {
+			t.Errorf("Expected row %d to be %s (%s), got %+v", expected[i].row, expected[i].status, expected[i].reason, result)
+		}
+	}
+	if report.Created != 1 || report.Updated != 1 || report.Skipped != 1 || report.Failed != 5 {
+		t.Errorf("Unexpected counts %+v", report)
+	}
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("Expected the existing users to be read in 2 pages, got %v", pages)
+	}
+
+	alice := added["alice"]
+	if alice == nil || alice.Owner != "built-in" || !alice.IsAdmin || alice.Phone != "12345678" || alice.Properties["department"] != "sales" {
+		t.Errorf("Unexpected added user %+v", alice)
+	}
+	if updatedColumns != "name,email,phone,password,properties,is_admin" {
+		t.Errorf("Unexpected updated columns %s", updatedColumns)
+	}
+	if updated.Email != "bob@example.org" || updated.Properties["team"] != "blue" || updated.Properties["department"] != "support" {
+		t.Errorf("Unexpected updated user %+v", updated)
+	}
+
+	input = `{"name": "grace", "email": "grace@example.com", "groups": ["built-in/staff"]}
+
+{"name": "bob", "displayName": "Bob"}
+{"name": "heidi", "nickname": "h"}
+`
+	report, err = client.ImportUsers(context.Background(), strings.NewReader(input), UserFormatJSONL, ImportUsersOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Created != 1 || report.Skipped != 1 || report.Failed != 1 || report.Results[1].Row != 3 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if grace := added["grace"]; grace == nil || len(grace.Groups) != 1 {
+		t.Errorf("Unexpected added user %+v", grace)
+	}
+}
+
+func TestImportUsersCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		switch r.URL.Path {
+		case "/api/get-organization":
+			return &Organization{Owner: "admin", Name: "built-in"}, nil
+		case "/api/get-users":
+			return &Response{Status: "ok", Data: []*User{}, Data2: 0}, nil
+		case "/api/add-user":
+			// the import is canceled while the request is in flight, the server notices
+			// the client going away once the body has been read
+			_, _ = io.Copy(io.Discard, r.Body)
+			cancel()
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}
+		return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+	})
+
+	input := "{\"name\": \"alice\"}\n{\"name\": \"bob\"}\n"
+	report, err := client.ImportUsers(ctx, strings.NewReader(input), UserFormatJSONL, ImportUsersOptions{Concurrency: 1})
+	if err != context.Canceled {
+		t.Fatalf("Expected the import to be canceled, got %v", err)
+	}
+	if report.Failed != 2 {
+		t.Errorf("Expected both rows to fail, got %+v", report)
+	}
+	for _, result := range report.Results {
+		if !strings.Contains(result.Reason, "context canceled") {
+			t.Errorf("Expected row %d to fail because of the cancellation, got %s", result.Row, result.Reason)
+		}
+	}
+}
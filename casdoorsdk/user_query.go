@@ -0,0 +1,235 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const queryUsersPageSize = 100
+
+// UserQuery is a search of the users of an organization. Casdoor searches the field, sorts and filters
+// a single group, see Query. Several groups, the tag, online, forbidden and created time conditions cannot
+// be sent to get-users, they are checked by Match, which QueryUsers and ExportUsersByQuery do.
+// The query can be built with its methods or as a struct literal.
+//
+//	users, err := client.QueryUsers(ctx, casdoorsdk.NewUserQuery().InGroups("staff").Forbidden(false).SortBy("name", casdoorsdk.SortAscend))
+type UserQuery struct {
+	Query
+	// Groups are the names of the groups the users must be in one of, directly.
+	Groups      []string
+	Tag         string
+	IsOnline    *bool
+	IsForbidden *bool
+	// CreatedFrom and CreatedTo select the users created in [CreatedFrom, CreatedTo), a zero time leaves its side open.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+func NewUserQuery() *UserQuery {
+	return &UserQuery{}
+}
+
+// Search returns the users whose field, given by its json name, contains the value, e.g. Search("email", "@example.com").
+func (q *UserQuery) Search(field string, value string) *UserQuery {
+	q.Query.Search(field, value)
+	return q
+}
+
+func (q *UserQuery) SortBy(field string, order string) *UserQuery {
+	q.Query.SortBy(field, order)
+	return q
+}
+
+// InGroups returns the users of any of the groups, given by their names.
+func (q *UserQuery) InGroups(names ...string) *UserQuery {
+	q.Groups = append(q.Groups, names...)
+	return q
+}
+
+// WithTag returns the users with the tag.
+func (q *UserQuery) WithTag(tag string) *UserQuery {
+	q.Tag = tag
+	return q
+}
+
+func (q *UserQuery) Online(isOnline bool) *UserQuery {
+	q.IsOnline = &isOnline
+	return q
+}
+
+func (q *UserQuery) Forbidden(isForbidden bool) *UserQuery {
+	q.IsForbidden = &isForbidden
+	return q
+}
+
+// CreatedBetween returns the users created in [from, to), a zero time leaves its side open.
+func (q *UserQuery) CreatedBetween(from time.Time, to time.Time) *UserQuery {
+	q.CreatedFrom = from
+	q.CreatedTo = to
+	return q
+}
+
+// QueryMap compiles the query to the parameters of get-users, to be passed to GetPaginationUsers. An error is
+// returned when the query has conditions get-users cannot check exactly, which only QueryUsers, ExportUsersByQuery
+// and Match check: several groups, the tag, online, forbidden and the created time.
+func (q *UserQuery) QueryMap() (map[string]string, error) {
+	var conditions []string
+	if len(q.Groups) > 1 {
+		conditions = append(conditions, "groups")
+	}
+	if q.Tag != "" {
+		conditions = append(conditions, "tag")
+	}
+	if q.IsOnline != nil {
+		conditions = append(conditions, "online")
+	}
+	if q.IsForbidden != nil {
+		conditions = append(conditions, "forbidden")
+	}
+	if !q.CreatedFrom.IsZero() || !q.CreatedTo.IsZero() {
+		conditions = append(conditions, "created time")
+	}
+	if len(conditions) != 0 {
+		return nil, fmt.Errorf("get-users cannot check the %s of the query, use QueryUsers or Match", strings.Join(conditions, ", "))
+	}
+
+	return q.getUsersQueryMap(), nil
+}
+
+// getUsersQueryMap returns the parameters of get-users narrowing the users down before Match. Without a search,
+// the tag is searched, which Casdoor does with a "like", so that Match still checks the tag exactly.
+func (q *UserQuery) getUsersQueryMap() map[string]string {
+	query := q.Query
+	if query.Field == "" && q.Tag != "" {
+		query.Search("tag", q.Tag)
+	}
+
+	res := query.QueryMap()
+	if len(q.Groups) == 1 {
+		res["groupName"] = url.QueryEscape(q.Groups[0])
+	}
+	return res
+}
+
+// Match checks the conditions of the query Casdoor does not check, the tag being matched exactly.
+func (q *UserQuery) Match(user *User) bool {
+	if len(q.Groups) != 0 && !isInGroups(user, q.Groups) {
+		return false
+	}
+	if q.Tag != "" && user.Tag != q.Tag {
+		return false
+	}
+	if q.IsOnline != nil && user.IsOnline != *q.IsOnline {
+		return false
+	}
+	if q.IsForbidden != nil && user.IsForbidden != *q.IsForbidden {
+		return false
+	}
+
+	if !q.CreatedFrom.IsZero() || !q.CreatedTo.IsZero() {
+		createdTime, err := time.Parse(time.RFC3339, user.CreatedTime)
+		if err != nil {
+			return false
+		}
+		if !q.CreatedFrom.IsZero() && createdTime.Before(q.CreatedFrom) {
+			return false
+		}
+		if !q.CreatedTo.IsZero() && !createdTime.Before(q.CreatedTo) {
+			return false
+		}
+	}
+	return true
+}
+
+// isInGroups returns true if the user is in one of the groups, given by their names.
+func isInGroups(user *User, groupNames []string) bool {
+	for _, groupId := range user.Groups {
+		name := groupId[strings.LastIndex(groupId, "/")+1:]
+		if containsString(groupNames, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueryUsers returns all the users of the organization of the client matching the query, reading them page by page.
+func (c *Client) QueryUsers(ctx context.Context, query *UserQuery) ([]*User, error) {
+	if query == nil {
+		query = NewUserQuery()
+	}
+
+	var users []*User
+	err := c.forEachUser(ctx, query.getUsersQueryMap(), query.Match, queryUsersPageSize, func(page []*User) error {
+		users = append(users, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// forEachUser calls f with each page of the users returned by get-users for queryMap, and accepted by match
+// unless it is nil. Without sorting, the users are sorted by created time, so that the pages do not overlap.
+func (c *Client) forEachUser(ctx context.Context, queryMap map[string]string, match func(user *User) bool, pageSize int, f func(users []*User) error) error {
+	if queryMap["sortField"] == "" {
+		sortedQueryMap := map[string]string{"sortField": "createdTime", "sortOrder": SortAscend}
+		for key, value := range queryMap {
+			sortedQueryMap[key] = value
+		}
+		queryMap = sortedQueryMap
+	}
+
+	for p := 1; ; p++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pageQueryMap := map[string]string{}
+		for key, value := range queryMap {
+			pageQueryMap[key] = value
+		}
+		pageQueryMap["owner"] = c.OrganizationName
+		pageQueryMap["p"] = strconv.Itoa(p)
+		pageQueryMap["pageSize"] = strconv.Itoa(pageSize)
+
+		var users []*User
+		total, err := c.doGetPaginationWithContext(ctx, c.GetUrl("get-users", pageQueryMap), &users)
+		if err != nil {
+			return err
+		}
+
+		var matched []*User
+		for _, user := range users {
+			if match == nil || match(user) {
+				matched = append(matched, user)
+			}
+		}
+		err = f(matched)
+		if err != nil {
+			return err
+		}
+
+		if len(users) < pageSize || p*pageSize >= total {
+			return nil
+		}
+	}
+}
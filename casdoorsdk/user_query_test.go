@@ -0,0 +1,106 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUserQuery(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		query      *UserQuery
+		expected   map[string]string
+		compilable bool
+		matched    []string
+	}{
+		{
+			query:      NewUserQuery(),
+			expected:   map[string]string{},
+			compilable: true,
+			matched:    []string{"alice", "bob", "carol", "dora"},
+		},
+		{
+			query:      NewUserQuery().Search("email", "a b@example.com").SortBy("name", ""),
+			expected:   map[string]string{"field": "email", "value": "a+b%40example.com", "sortField": "name", "sortOrder": "ascend"},
+			compilable: true,
+			matched:    []string{"alice", "bob", "carol", "dora"},
+		},
+		{
+			query:      NewUserQuery().WithTag("staff").InGroups("support").Forbidden(false),
+			expected:   map[string]string{"field": "tag", "value": "staff", "groupName": "support"},
+			compilable: false,
+			matched:    []string{"alice"},
+		},
+		{
+			query:      &UserQuery{Groups: []string{"support", "sales"}},
+			expected:   map[string]string{},
+			compilable: false,
+			matched:    []string{"alice", "carol"},
+		},
+		{
+			query:      NewUserQuery().Search("name", "o").WithTag("staff").Online(true),
+			expected:   map[string]string{"field": "name", "value": "o"},
+			compilable: false,
+			matched:    []string{"bob"},
+		},
+		{
+			query:      NewUserQuery().CreatedBetween(from, to).SortBy("createdTime", SortDescend),
+			expected:   map[string]string{"sortField": "createdTime", "sortOrder": "descend"},
+			compilable: false,
+			matched:    []string{"alice"},
+		},
+		{
+			query:      NewUserQuery().CreatedBetween(time.Time{}, to),
+			expected:   map[string]string{},
+			compilable: false,
+			matched:    []string{"alice", "carol"},
+		},
+	}
+
+	users := []*User{
+		{Name: "alice", Tag: "staff", Groups: []string{"built-in/support"}, CreatedTime: "2023-01-15T10:00:00+08:00"},
+		{Name: "bob", Tag: "staff", IsOnline: true, IsForbidden: true, CreatedTime: "2023-03-01T10:00:00Z"},
+		{Name: "carol", Tag: "guest", Groups: []string{"built-in/sales"}, CreatedTime: "2022-12-31T23:00:00Z"},
+		{Name: "dora", Tag: "staff-old", IsOnline: true},
+	}
+	for i, tc := range testCases {
+		queryMap := tc.query.getUsersQueryMap()
+		if !reflect.DeepEqual(queryMap, tc.expected) {
+			t.Errorf("Case %d: expected query %v, but got %v", i, tc.expected, queryMap)
+		}
+		compiled, err := tc.query.QueryMap()
+		if tc.compilable && (err != nil || !reflect.DeepEqual(compiled, tc.expected)) {
+			t.Errorf("Case %d: expected the compiled query %v, but got %v, %v", i, tc.expected, compiled, err)
+		}
+		if !tc.compilable && err == nil {
+			t.Errorf("Case %d: expected an error for conditions get-users cannot check", i)
+		}
+
+		matched := []string{}
+		for _, user := range users {
+			if tc.query.Match(user) {
+				matched = append(matched, user.Name)
+			}
+		}
+		if !reflect.DeepEqual(matched, tc.matched) {
+			t.Errorf("Case %d: expected users %v, but got %v", i, tc.matched, matched)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateUserChanged(t *testing.T) {
+	stored := &User{Owner: "built-in", Name: "alice", UpdatedTime: "2023-01-01T00:00:00Z", DisplayName: "Alice", Email: "alice@example.com"}
+	var updates []string
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		switch r.URL.Path {
+		case "/api/get-user":
+			return stored, nil
+		case "/api/update-user":
+			updates = append(updates, r.URL.Query().Get("id")+" "+r.URL.Query().Get("columns"))
+			return "Affected", nil
+		}
+		return nil, nil
+	})
+
+	before := *stored
+	after := before
+	after.DisplayName = "Alice A."
+	after.UpdatedTime = "2023-06-01T00:00:00Z"
+	ok, err := client.UpdateUserChanged(context.Background(), &before, &after)
+	if err != nil || !ok {
+		t.Fatalf("Expected the user to be updated, got %v, %v", ok, err)
+	}
+	if len(updates) != 1 || updates[0] != "built-in/alice display_name" {
+		t.Errorf("Unexpected updates %v", updates)
+	}
+
+	ok, err = client.UpdateUserChanged(context.Background(), &before, &before)
+	if err != nil || ok || len(updates) != 1 {
+		t.Errorf("Expected nothing to be sent without changes, got %v, %v", ok, err)
+	}
+
+	_, err = client.UpdateUserChanged(context.Background(), &before, nil)
+	if err == nil || len(updates) != 1 {
+		t.Errorf("Expected a nil user to be rejected, got %v", err)
+	}
+
+	stored.UpdatedTime = "2023-02-01T00:00:00Z"
+	_, err = client.UpdateUserChanged(context.Background(), &before, &after)
+	if !errors.Is(err, ErrUserChanged) || len(updates) != 1 {
+		t.Errorf("Expected ErrUserChanged, but got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import "context"
+
+func SendVerificationCode(ctx context.Context, dest VerificationDest, destType string, method string) error {
+	return globalClient.SendVerificationCode(ctx, dest, destType, method)
+}
+
+func VerifyCode(ctx context.Context, dest VerificationDest, code string) error {
+	return globalClient.VerifyCode(ctx, dest, code)
+}
+
+func VerifyUserEmail(ctx context.Context, user *User, code string) (bool, error) {
+	return globalClient.VerifyUserEmail(ctx, user, code)
+}
+
+func VerifyUserPhone(ctx context.Context, user *User, code string) error {
+	return globalClient.VerifyUserPhone(ctx, user, code)
+}
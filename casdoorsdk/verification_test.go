@@ -0,0 +1,107 @@
+// Copyright 2026 The Casdoor Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casdoorsdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestVerificationCode(t *testing.T) {
+	var sent []string
+	var updates []string
+	client := newFakeClient(t, func(r *http.Request) (interface{}, error) {
+		switch r.URL.Path {
+		case "/api/send-verification-code":
+			dest := r.FormValue("dest")
+			sent = append(sent, r.FormValue("type")+" "+dest+" "+r.FormValue("countryCode")+" "+r.FormValue("method")+" "+r.FormValue("applicationId"))
+			switch dest {
+			case "bob@example.com":
+				return nil, errors.New("Turing test failed.")
+			case "carol@example.com":
+				return nil, errors.New("The captcha provider of the email is not enabled")
+			}
+		case "/api/verify-code":
+			var form map[string]string
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &form)
+			if form["code"] != "123456" || form["organization"] != "built-in" {
+				return nil, errors.New("Wrong verification code!")
+			}
+		case "/api/update-user":
+			updates = append(updates, r.URL.Query().Get("id")+" "+r.URL.Query().Get("columns"))
+			if r.URL.Query().Get("id") == "built-in/bob" {
+				return nil, errors.New("The user is not found")
+			}
+			return "Affected", nil
+		}
+		return nil, nil
+	})
+	ctx := context.Background()
+
+	err := client.SendVerificationCode(ctx, EmailDest("alice@example.com"), VerificationTypeEmail, VerificationMethodSignup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.SendVerificationCode(ctx, PhoneDest("5551234", "US"), VerificationTypePhone, VerificationMethodLogin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"email alice@example.com  signup admin/app-built-in", "phone 5551234 US login admin/app-built-in"}
+	if len(sent) != 2 || sent[0] != expected[0] || sent[1] != expected[1] {
+		t.Errorf("Expected sent codes %q, but got %q", expected, sent)
+	}
+
+	if err = client.SendVerificationCode(ctx, PhoneDest("5551234", ""), VerificationTypePhone, VerificationMethodLogin); err == nil {
+		t.Errorf("Expected a phone without country code to be rejected")
+	}
+
+	var captchaErr *CaptchaRequiredError
+	err = client.SendVerificationCode(ctx, EmailDest("bob@example.com"), VerificationTypeEmail, VerificationMethodSignup)
+	if !errors.As(err, &captchaErr) {
+		t.Errorf("Expected a CaptchaRequiredError, but got %v", err)
+	}
+	err = client.SendVerificationCode(ctx, EmailDest("carol@example.com"), VerificationTypeEmail, VerificationMethodSignup)
+	if err == nil || errors.As(err, &captchaErr) {
+		t.Errorf("Expected only the known messages to be captcha errors, but got %v", err)
+	}
+
+	user := &User{Owner: "built-in", Name: "alice", Email: "alice@example.com", Phone: "5551234", CountryCode: "US"}
+	ok, err := client.VerifyUserEmail(ctx, user, "654321")
+	if err == nil || ok || user.EmailVerified {
+		t.Errorf("Expected a wrong code to be rejected, got %v, %v", ok, err)
+	}
+	ok, err = client.VerifyUserEmail(ctx, user, "123456")
+	if err != nil || !ok || !user.EmailVerified {
+		t.Errorf("Expected the email to be verified, got %v, %v", ok, err)
+	}
+	if len(updates) != 1 || updates[0] != "built-in/alice email_verified" {
+		t.Errorf("Unexpected updates %v", updates)
+	}
+
+	bob := &User{Owner: "built-in", Name: "bob", Email: "bob@example.com"}
+	ok, err = client.VerifyUserEmail(ctx, bob, "123456")
+	if err == nil || ok || bob.EmailVerified {
+		t.Errorf("Expected the email to stay unverified when the update fails, got %v, %v", ok, err)
+	}
+
+	if err = client.VerifyUserPhone(ctx, user, "123456"); err != nil {
+		t.Errorf("Expected the phone to be verified, but got %v", err)
+	}
+}
@@ -16,7 +16,6 @@ package casdoorsdk
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 )
@@ -76,17 +75,13 @@ func (c *Client) GetPaginationWebhooks(p int, pageSize int, queryMap map[string]
 
 	url := c.GetUrl("get-models", queryMap)
 
-	response, err := c.DoGetResponse(url)
+	var webhooks []*Webhook
+	total, err := c.doGetPagination(url, &webhooks)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	webhooks, ok := response.Data.([]*Webhook)
-	if !ok {
-		return nil, 0, errors.New("response data format is incorrect")
-	}
-
-	return webhooks, int(response.Data2.(float64)), nil
+	return webhooks, total, nil
 }
 
 func (c *Client) GetWebhook(name string) (*Webhook, error) {